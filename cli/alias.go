@@ -0,0 +1,136 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/urfave/cli/v2"
+	"github.com/voidint/g/pkg/alias"
+	"github.com/voidint/g/version"
+)
+
+// aliasesFile is the file in the g home holding the aliases.
+const aliasesFile = "aliases.json"
+
+func loadAliases() (alias.Aliases, error) {
+	return alias.Load(filepath.Join(ghomeDir, aliasesFile))
+}
+
+// resolveAlias returns the version or version constraint named by the alias, or the name itself if it is not an alias.
+func resolveAlias(name string) (string, error) {
+	as, err := loadAliases()
+	if err != nil {
+		return "", err
+	}
+	vname, ok := as.Resolve(name)
+	if ok {
+		fmt.Fprintf(os.Stderr, "Alias %q refers to <%s>\n", name, vname)
+	}
+	return vname, nil
+}
+
+// installedAliases maps the installed versions to the names of the aliases referring to them.
+func installedAliases(versions []*version.Version) map[string][]string {
+	as, err := loadAliases()
+	if err != nil || len(as) == 0 {
+		return nil
+	}
+	m := make(map[string][]string)
+	for _, a := range as.List() {
+		if target, _ := matchLocalVersion(versions, a.Version); target != "" {
+			m[target] = append(m[target], a.Name)
+		}
+	}
+	return m
+}
+
+func setAlias(ctx *cli.Context) (err error) {
+	name, vname := ctx.Args().Get(0), ctx.Args().Get(1)
+	if name == "" || vname == "" {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+	if err = alias.ValidateName(name); err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+	if err = alias.ValidateVersion(vname); err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+
+	as, err := loadAliases()
+	if err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+	as[name] = vname
+	if err = as.Save(filepath.Join(ghomeDir, aliasesFile)); err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+	fmt.Printf("%s -> %s\n", name, vname)
+	return nil
+}
+
+func removeAlias(ctx *cli.Context) (err error) {
+	name := ctx.Args().First()
+	if name == "" {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+	as, err := loadAliases()
+	if err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+	if _, ok := as[name]; !ok {
+		return cli.Exit(wrapstring(fmt.Sprintf("Alias %q does not exist", name)), 1)
+	}
+	delete(as, name)
+	if err = as.Save(filepath.Join(ghomeDir, aliasesFile)); err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+	fmt.Printf("Removed alias %q\n", name)
+	return nil
+}
+
+func listAliases(ctx *cli.Context) (err error) {
+	as, err := loadAliases()
+	if err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+	if ctx.String("output") == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "    ")
+		_ = enc.Encode(as.List())
+		return nil
+	}
+	if len(as) == 0 {
+		fmt.Printf("No alias defined yet\n\n")
+		return nil
+	}
+	printAliases(os.Stdout, as)
+	return nil
+}
+
+func printAliases(out io.Writer, as alias.Aliases) {
+	for _, a := range as.List() {
+		_, _ = fmt.Fprintf(out, "%s -> %s\n", a.Name, a.Version)
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/voidint/g/pkg/alias"
+	"github.com/voidint/g/version"
+)
+
+func Test_installedAliases(t *testing.T) {
+	ghomeDir = t.TempDir()
+	versions := []*version.Version{version.MustNew("1.20.14"), version.MustNew("1.21.4")}
+
+	assert.Nil(t, installedAliases(versions))
+
+	as := alias.Aliases{"legacy": "1.20", "work": "1.21.4", "old": "1.20.14", "future": "1.30.0"}
+	assert.Nil(t, as.Save(filepath.Join(ghomeDir, aliasesFile)))
+	m := installedAliases(versions)
+	assert.Equal(t, map[string][]string{"1.20.14": {"legacy", "old"}, "1.21.4": {"work"}}, m)
+
+	var got strings.Builder
+	render(textMode, map[string]bool{"1.21.4": true}, m, versions, &got)
+	assert.Equal(t, "  1.20.14 (legacy, old)\n* 1.21.4 (work)\n", got.String())
+
+	vname, err := resolveAlias("work")
+	assert.Nil(t, err)
+	assert.Equal(t, "1.21.4", vname)
+	vname, err = resolveAlias("1.22.0")
+	assert.Nil(t, err)
+	assert.Equal(t, "1.22.0", vname)
+}
+
+func Test_resolveAlias_malformed(t *testing.T) {
+	ghomeDir = t.TempDir()
+	assert.Nil(t, os.WriteFile(filepath.Join(ghomeDir, aliasesFile), []byte("["), 0600))
+	_, err := resolveAlias("work")
+	assert.NotNil(t, err)
+}
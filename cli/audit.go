@@ -0,0 +1,112 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+	"github.com/voidint/g/pkg/vulndb"
+)
+
+type auditResult struct {
+	Version string           `json:"version"`
+	InUse   bool             `json:"inUse"`
+	Vulns   []vulndb.Finding `json:"vulns"`
+}
+
+func audit(ctx *cli.Context) (err error) {
+	vnames := ctx.Args().Slice()
+	if len(vnames) == 0 {
+		items, err := listLocalVersions(versionsDir)
+		if err != nil {
+			return cli.Exit(errstring(err), 1)
+		}
+		for _, item := range items {
+			vnames = append(vnames, item.Name())
+		}
+	}
+	if len(vnames) == 0 {
+		fmt.Printf("No version installed yet\n\n")
+		return nil
+	}
+
+	db, err := vulndb.Load(ctx.String("db"))
+	if err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+
+	inused := inuse(goroot)
+	results := make([]*auditResult, 0, len(vnames))
+	var vulnerable bool
+	for _, vname := range vnames {
+		findings, err := db.Check(vname)
+		if err != nil {
+			return cli.Exit(errstring(err), 1)
+		}
+		if len(findings) > 0 {
+			vulnerable = true
+		}
+		results = append(results, &auditResult{Version: vname, InUse: vname == inused, Vulns: findings})
+	}
+
+	if ctx.String("output") == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "    ")
+		_ = enc.Encode(&results)
+	} else {
+		printAuditResults(os.Stdout, results)
+	}
+
+	if vulnerable {
+		return cli.Exit("", 1)
+	}
+	return nil
+}
+
+func printAuditResults(out io.Writer, results []*auditResult) {
+	for _, result := range results {
+		name := "go" + result.Version
+		if result.InUse {
+			name += " (in use)"
+		}
+		if len(result.Vulns) == 0 {
+			_, _ = fmt.Fprintf(out, "%s: no known vulnerabilities\n", name)
+			continue
+		}
+		_, _ = fmt.Fprintf(out, "%s: %d vulnerabilities\n", name, len(result.Vulns))
+		for _, vuln := range result.Vulns {
+			id := vuln.ID
+			if len(vuln.Aliases) > 0 {
+				id += " (" + strings.Join(vuln.Aliases, ", ") + ")"
+			}
+			_, _ = fmt.Fprintf(out, "  %s %s\n", id, vuln.Summary)
+			if vuln.Fixed != "" {
+				_, _ = fmt.Fprintf(out, "    fixed in: go%s\n", vuln.Fixed)
+			} else {
+				_, _ = fmt.Fprintf(out, "    fixed in: not fixed yet\n")
+			}
+		}
+	}
+}
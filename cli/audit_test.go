@@ -0,0 +1,50 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/voidint/g/pkg/vulndb"
+)
+
+func Test_printAuditResults(t *testing.T) {
+	var buf bytes.Buffer
+	printAuditResults(&buf, []*auditResult{
+		{
+			Version: "1.21.3",
+			InUse:   true,
+			Vulns: []vulndb.Finding{
+				{ID: "GO-2023-2185", Aliases: []string{"CVE-2023-45284"}, Summary: "Insecure parsing of Windows paths", Fixed: "1.21.4"},
+				{ID: "GO-0000-0001", Summary: "Unfixed"},
+			},
+		},
+		{Version: "1.21.4", Vulns: []vulndb.Finding{}},
+	})
+	assert.Equal(t, `go1.21.3 (in use): 2 vulnerabilities
+  GO-2023-2185 (CVE-2023-45284) Insecure parsing of Windows paths
+    fixed in: go1.21.4
+  GO-0000-0001 Unfixed
+    fixed in: not fixed yet
+go1.21.4: no known vulnerabilities
+`, buf.String())
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+	"github.com/voidint/g/collector"
+	"github.com/voidint/g/collector/official"
+	"github.com/voidint/g/pkg/checksum"
+	"github.com/voidint/g/pkg/checksumdb"
+	"github.com/voidint/g/pkg/errs"
+)
+
+// checksumsFile is the file in the g home holding the checksums refreshed by 'g checksums update'.
+// It takes precedence over the checksums embedded in g.
+const checksumsFile = "SHA256SUMS"
+
+// officialPages are the official download pages, whose JSON listing is the only source of the official checksums.
+var officialPages = []string{collector.OfficialDownloadPageURL, collector.OriginalOfficialDownloadPageURL, collector.CNDownloadPageURL}
+
+func updateChecksums(ctx *cli.Context) (err error) {
+	url := ctx.String("url")
+	if !isOfficialPage(url) {
+		return cli.Exit(wrapstring(fmt.Sprintf("%q is not an official download page, one of: [%s]", url, strings.Join(officialPages, "|"))), 1)
+	}
+	digests, err := official.Digests(url)
+	if err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+	if len(digests) == 0 {
+		return cli.Exit(errstring(errs.ErrChecksumFileNotFound), 1)
+	}
+
+	output := ctx.String("output")
+	if output == "" {
+		output = filepath.Join(ghomeDir, checksumsFile)
+	}
+	if err = writeChecksums(output, digests); err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+	fmt.Printf("Saved %d checksums to %s\n", len(digests), output)
+	return nil
+}
+
+// isOfficialPage reports whether the URL is one of the official download pages.
+func isOfficialPage(url string) bool {
+	url = strings.TrimSuffix(strings.TrimSpace(url), "/") + "/"
+	for _, page := range officialPages {
+		if url == page {
+			return true
+		}
+	}
+	return false
+}
+
+// writeChecksums replaces the checksums file atomically, unless the checksums contradict the embedded official ones.
+func writeChecksums(filename string, digests map[string]string) (err error) {
+	f, err := os.CreateTemp(filepath.Dir(filename), filepath.Base(filename)+".*.tmp")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer os.Remove(f.Name())
+
+	if err = checksum.WriteFile(f, digests); err != nil {
+		_ = f.Close()
+		return errors.WithStack(err)
+	}
+	if err = f.Close(); err != nil {
+		return errors.WithStack(err)
+	}
+	if _, err = checksumdb.New(f.Name()); err != nil {
+		return err
+	}
+	return errors.WithStack(os.Rename(f.Name(), filename))
+}
+
+// loadChecksumDB makes the official checksums available to the checksum verification of packages.
+func loadChecksumDB() error {
+	db, err := checksumdb.New(filepath.Join(ghomeDir, checksumsFile))
+	if err != nil {
+		return err
+	}
+	checksumdb.SetDefault(db)
+	return nil
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/voidint/g/pkg/checksumdb"
+	"github.com/voidint/g/pkg/errs"
+)
+
+func Test_isOfficialPage(t *testing.T) {
+	assert.True(t, isOfficialPage("https://go.dev/dl/"))
+	assert.True(t, isOfficialPage("https://go.dev/dl"))
+	assert.True(t, isOfficialPage("https://golang.google.cn/dl/"))
+	assert.False(t, isOfficialPage("https://mirrors.aliyun.com/golang/"))
+	assert.False(t, isOfficialPage("https://go.dev.example.com/dl/"))
+	assert.False(t, isOfficialPage(""))
+}
+
+func Test_writeChecksums(t *testing.T) {
+	ghomeDir = t.TempDir()
+	filename := filepath.Join(ghomeDir, checksumsFile)
+
+	assert.Nil(t, writeChecksums(filename, map[string]string{
+		"go1.99.0.linux-amd64.tar.gz": "0000000000000000000000000000000000000000000000000000000000000000",
+	}))
+	entries, err := os.ReadDir(ghomeDir)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(entries))
+
+	defer checksumdb.SetDefault(checksumdb.Default())
+	assert.Nil(t, loadChecksumDB())
+
+	digest, ok := checksumdb.Default().Lookup("go1.99.0.linux-amd64.tar.gz")
+	assert.True(t, ok)
+	assert.Equal(t, "0000000000000000000000000000000000000000000000000000000000000000", digest)
+
+	_, ok = checksumdb.Default().Lookup("go1.21.4.linux-amd64.tar.gz")
+	assert.True(t, ok)
+
+	t.Run("与内嵌的官方校验和冲突", func(t *testing.T) {
+		err := writeChecksums(filename, map[string]string{
+			"go1.21.4.linux-amd64.tar.gz": "0000000000000000000000000000000000000000000000000000000000000000",
+		})
+		assert.ErrorIs(t, err, errs.ErrChecksumConflict)
+
+		digest, ok := checksumdb.Default().Lookup("go1.99.0.linux-amd64.tar.gz")
+		assert.True(t, ok) // the checksums file is left untouched
+		assert.Equal(t, "0000000000000000000000000000000000000000000000000000000000000000", digest)
+		entries, err := os.ReadDir(ghomeDir)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(entries))
+	})
+}
@@ -6,12 +6,14 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/fatih/color"
 	"github.com/urfave/cli/v2"
 	"github.com/voidint/g/build"
+	"github.com/voidint/g/pkg/flock"
 	"github.com/voidint/g/version"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
@@ -35,6 +37,14 @@ func Run() {
 		{Name: "voidint", Email: "voidint@126.com"},
 	}
 
+	app.Flags = []cli.Flag{
+		&cli.DurationFlag{
+			Name:    "lock-timeout",
+			Usage:   "Maximum time to wait for another g process to release the g home (0 means fail immediately)",
+			Value:   defaultLockTimeout,
+			EnvVars: []string{lockTimeoutEnv},
+		},
+	}
 	app.Before = func(ctx *cli.Context) (err error) {
 		ghomeDir = ghome()
 		goroot = filepath.Join(ghomeDir, "go")
@@ -87,6 +97,13 @@ const (
 	experimentalEnv = "G_EXPERIMENTAL"
 	homeEnv         = "G_HOME"
 	mirrorEnv       = "G_MIRROR"
+	lockTimeoutEnv  = "G_LOCK_TIMEOUT"
+	keyringEnv      = "G_KEYRING"
+	gosumdbEnv      = "GOSUMDB"
+	goproxyEnv      = "GOPROXY"
+	govulndbEnv     = "GOVULNDB"
+	goVersionEnv    = "G_GO_VERSION"
+	autoInstallEnv  = "G_AUTO_INSTALL"
 )
 
 const (
@@ -105,6 +122,49 @@ func ghome() (dir string) {
 	return filepath.Join(homeDir, ".g")
 }
 
+const (
+	// lockFile is the name of the advisory lock file in the g home.
+	lockFile = ".lock"
+	// lockHeldEnv tells the child g process that its parent holds the lock on its behalf. The value is the pid of the parent,
+	// so that the processes merely inheriting the variable do not skip the lock.
+	lockHeldEnv = "_G_LOCK_HELD"
+	// defaultLockTimeout is how long mutating commands wait for a concurrent g process by default.
+	defaultLockTimeout = 5 * time.Minute
+)
+
+// lockHeld reports whether the process holds the lock.
+var lockHeld bool
+
+// withLock serializes the mutating command across processes sharing the same g home.
+func withLock(action cli.ActionFunc) cli.ActionFunc {
+	return func(ctx *cli.Context) error {
+		if lockHeldByParent() {
+			// The parent g process holds the lock, e.g. while installing the version for 'g use --install'.
+			lockHeld = true
+			return action(ctx)
+		}
+		lock := flock.New(filepath.Join(ghomeDir, lockFile))
+		if err := lock.LockWithTimeout(ctx.Duration("lock-timeout"), func() {
+			fmt.Fprintln(os.Stderr, "Waiting for another g process to finish...")
+		}); err != nil {
+			return cli.Exit(errstring(err), 1)
+		}
+		defer lock.Unlock()
+		lockHeld = true
+		return action(ctx)
+	}
+}
+
+// lockHeldByParent reports whether the parent process handed its lock over, and removes the variable so that it goes no further.
+func lockHeldByParent() bool {
+	token, ok := os.LookupEnv(lockHeldEnv)
+	if !ok {
+		return false
+	}
+	_ = os.Unsetenv(lockHeldEnv)
+	return token == strconv.Itoa(os.Getppid())
+}
+
 // inuse detects currently active Go version.
 func inuse(goroot string) (version string) {
 	p, _ := os.Readlink(goroot)
@@ -121,7 +181,7 @@ func installed() (versions map[string]bool) {
 	inused := inuse(goroot)
 	versions = make(map[string]bool, 0)
 	for _, d := range dirs {
-		if !d.IsDir() {
+		if !d.IsDir() || strings.HasPrefix(d.Name(), ".") {
 			continue
 		}
 		vname := d.Name()
@@ -135,6 +195,7 @@ type versionOut struct {
 	Version   string            `json:"version"`
 	InUse     bool              `json:"inUse"`
 	Installed bool              `json:"installed"`
+	Aliases   []string          `json:"aliases,omitempty"`
 	Packages  []version.Package `json:"packages,omitempty"`
 }
 
@@ -143,8 +204,8 @@ const (
 	jsonMode = 1
 )
 
-// render outputs version list in specified format.
-func render(mode uint8, installed map[string]bool, items []*version.Version, out io.Writer) {
+// render outputs version list in specified format. The aliases map the versions to the names of the aliases referring to them.
+func render(mode uint8, installed map[string]bool, aliases map[string][]string, items []*version.Version, out io.Writer) {
 	switch mode {
 	case jsonMode:
 		vs := make([]versionOut, 0, len(items))
@@ -152,6 +213,7 @@ func render(mode uint8, installed map[string]bool, items []*version.Version, out
 		for _, item := range items {
 			vo := versionOut{
 				Version:  item.Name(),
+				Aliases:  aliases[item.Name()],
 				Packages: item.Packages(),
 			}
 			if inuse, found := installed[item.Name()]; found {
@@ -167,14 +229,18 @@ func render(mode uint8, installed map[string]bool, items []*version.Version, out
 
 	default:
 		for _, item := range items {
+			name := item.Name()
+			if names := aliases[item.Name()]; len(names) > 0 {
+				name += " (" + strings.Join(names, ", ") + ")"
+			}
 			if inused, found := installed[item.Name()]; found {
 				if inused {
-					_, _ = color.New(color.FgGreen).Fprintf(out, "* %s\n", item.Name())
+					_, _ = color.New(color.FgGreen).Fprintf(out, "* %s\n", name)
 				} else {
-					_, _ = color.New(color.FgGreen).Fprintf(out, "  %s\n", item.Name())
+					_, _ = color.New(color.FgGreen).Fprintf(out, "  %s\n", name)
 				}
 			} else {
-				_, _ = fmt.Fprintf(out, "  %s\n", item.Name())
+				_, _ = fmt.Fprintf(out, "  %s\n", name)
 			}
 		}
 	}
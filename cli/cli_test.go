@@ -26,6 +26,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -71,7 +72,7 @@ func Test_render(t *testing.T) {
 		}
 		sort.Sort(version.Collection(items))
 
-		render(textMode, map[string]bool{"1.8.1": true}, items, &got)
+		render(textMode, map[string]bool{"1.8.1": true}, nil, items, &got)
 		assert.Equal(t, "  1.7\n* 1.8.1\n  1.10beta2\n  1.19beta1\n  1.21rc4\n  1.21.0\n", got.String())
 	})
 
@@ -88,7 +89,7 @@ func Test_render(t *testing.T) {
 		sort.Sort(version.Collection(items))
 
 		installed := map[string]bool{"1.8.1": true}
-		render(jsonMode, installed, items, &actual)
+		render(jsonMode, installed, nil, items, &actual)
 
 		vs := make([]versionOut, 0, len(items))
 		for _, item := range items {
@@ -123,3 +124,24 @@ func Test_errstring(t *testing.T) {
 		assert.Equal(t, "[g] Hello world", errstring(errors.New("hello world")))
 	})
 }
+
+func Test_lockHeldByParent(t *testing.T) {
+	t.Run("未设置环境变量", func(t *testing.T) {
+		assert.Nil(t, os.Unsetenv(lockHeldEnv))
+		assert.False(t, lockHeldByParent())
+	})
+
+	t.Run("父进程持有锁", func(t *testing.T) {
+		t.Setenv(lockHeldEnv, strconv.Itoa(os.Getppid()))
+		assert.True(t, lockHeldByParent())
+		_, ok := os.LookupEnv(lockHeldEnv)
+		assert.False(t, ok)
+	})
+
+	t.Run("继承自其他进程的环境变量", func(t *testing.T) {
+		t.Setenv(lockHeldEnv, "1")
+		assert.False(t, lockHeldByParent())
+		_, ok := os.LookupEnv(lockHeldEnv)
+		assert.False(t, ok)
+	})
+}
@@ -23,6 +23,8 @@ import (
 	"fmt"
 
 	"github.com/urfave/cli/v2"
+	"github.com/voidint/g/collector"
+	"github.com/voidint/g/pkg/vulndb"
 )
 
 var (
@@ -48,8 +50,16 @@ var (
 			Name:      "ls-remote",
 			Aliases:   []string{"lr", "lsr"},
 			Usage:     "List remote versions available for install",
-			UsageText: "g ls-remote [stable|archived|unstable]",
+			UsageText: "g ls-remote [--prerelease] [--explain] [stable|oldstable|next|min-go|archived|unstable|<version>]",
 			Flags: []cli.Flag{
+				&cli.BoolFlag{
+					Name:  "explain",
+					Usage: "Print the versions considered and why they are listed or not",
+				},
+				&cli.BoolFlag{
+					Name:  "prerelease",
+					Usage: "Let version constraints match release candidates and betas too",
+				},
 				&cli.StringFlag{
 					Name:    "output",
 					Aliases: []string{"o"},
@@ -63,17 +73,72 @@ var (
 		},
 		{
 			Name:      "use",
-			Usage:     "Switch to specified version. Uses go.mod if available and version is omitted.",
-			UsageText: "g use <version>",
-			Action:    use,
+			Usage:     "Switch to specified version. Uses the version selected by the project files if version is omitted.",
+			UsageText: "g use [--local] [--install] [--explain [-o text|json]] <version>",
+			Action:    withLock(use),
+			Before: func(ctx *cli.Context) error {
+				return validateLsFlag(ctx)
+			},
+			Flags: []cli.Flag{
+				&cli.BoolFlag{
+					Name:  "explain",
+					Usage: "Print how the version is resolved against the installed versions instead of switching to it",
+				},
+				&cli.StringFlag{
+					Name:    "output",
+					Aliases: []string{"o"},
+					Usage:   "Output format of the explanation. One of: [text|json]",
+				},
+				&cli.BoolFlag{
+					Name:    "install",
+					Usage:   "Install the version if no installed version matches",
+					EnvVars: []string{autoInstallEnv},
+				},
+				&cli.BoolFlag{
+					Name:  "local",
+					Usage: "Also record the version in the .go-version (or existing .tool-versions) file of the working directory",
+				},
+			},
+		},
+		{
+			Name:      "current",
+			Usage:     "Show the selected version and the file or setting that decided it",
+			UsageText: "g current",
+			Action:    current,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    "output",
+					Aliases: []string{"o"},
+					Usage:   "Output format. One of: [text|json]",
+				},
+			},
+			Before: func(ctx *cli.Context) error {
+				return validateLsFlag(ctx)
+			},
 		},
 		{
 			Name:      "install",
 			Aliases:   []string{"i"},
-			Usage:     "Download and install a version",
-			UsageText: "g install <version>",
-			Action:    install,
+			Usage:     "Download and install a version. Installs the version selected by the project files if version is omitted.",
+			UsageText: "g install [--prerelease] [--explain [-o text|json]] [--os <goos> --arch <goarch> --download-only [--extract-to <dir>]] [version|stable|oldstable|next|min-go]",
+			Action:    withLock(install),
+			Before: func(ctx *cli.Context) error {
+				return validateLsFlag(ctx)
+			},
 			Flags: []cli.Flag{
+				&cli.BoolFlag{
+					Name:  "explain",
+					Usage: "Print how the version is resolved against the versions of the mirror instead of installing it",
+				},
+				&cli.StringFlag{
+					Name:    "output",
+					Aliases: []string{"o"},
+					Usage:   "Output format of the explanation. One of: [text|json]",
+				},
+				&cli.BoolFlag{
+					Name:  "prerelease",
+					Usage: "Let version constraints match release candidates and betas too",
+				},
 				&cli.BoolFlag{
 					Name:    "nouse",
 					Aliases: []string{"n"},
@@ -83,13 +148,99 @@ var (
 					Name:  "skip-checksum",
 					Usage: "Skip checksum verification",
 				},
+				&cli.BoolFlag{
+					Name:  "non-interactive",
+					Usage: "Never prompt: install the first package and refuse packages without checksum",
+				},
+				&cli.BoolFlag{
+					Name:  "verify-signature",
+					Usage: "Refuse packages without a valid OpenPGP signature",
+				},
+				&cli.BoolFlag{
+					Name:  "sumdb",
+					Usage: "Install the golang.org/toolchain module from GOPROXY verified against the checksum database (GOSUMDB)",
+				},
+				&cli.StringFlag{
+					Name:    "keyring",
+					Usage:   "OpenPGP keyring used to verify signatures, defaults to the embedded Go release signing key",
+					EnvVars: []string{keyringEnv},
+				},
+				&cli.StringFlag{
+					Name:  "os",
+					Usage: "Operating system (GOOS) of the package, defaults to the current one",
+				},
+				&cli.StringFlag{
+					Name:  "arch",
+					Usage: "Architecture (GOARCH) of the package, defaults to the current one",
+				},
+				&cli.BoolFlag{
+					Name:  "download-only",
+					Usage: "Only download and verify the package into the downloads directory, required for packages of other platforms",
+				},
+				&cli.StringFlag{
+					Name:  "extract-to",
+					Usage: "Also extract the downloaded package into the directory, out of reach of 'g use'. Implies --download-only",
+				},
+			},
+		},
+		{
+			Name:      "download",
+			Usage:     "Download and verify the package of a version, for the current platform or another one, without installing it",
+			UsageText: "g download [--prerelease] [--explain [-o text|json]] [--os <goos>] [--arch <goarch>] [--extract-to <dir>] [version|stable|oldstable|next|min-go]",
+			Action:    withLock(install),
+			Before: func(ctx *cli.Context) error {
+				return validateLsFlag(ctx)
+			},
+			Flags: []cli.Flag{
+				&cli.BoolFlag{
+					Name:  "explain",
+					Usage: "Print how the version is resolved against the versions of the mirror instead of downloading it",
+				},
+				&cli.StringFlag{
+					Name:    "output",
+					Aliases: []string{"o"},
+					Usage:   "Output format of the explanation. One of: [text|json]",
+				},
+				&cli.BoolFlag{
+					Name:  "prerelease",
+					Usage: "Let version constraints match release candidates and betas too",
+				},
+				&cli.StringFlag{
+					Name:  "os",
+					Usage: "Operating system (GOOS) of the package, defaults to the current one",
+				},
+				&cli.StringFlag{
+					Name:  "arch",
+					Usage: "Architecture (GOARCH) of the package, defaults to the current one",
+				},
+				&cli.StringFlag{
+					Name:  "extract-to",
+					Usage: "Also extract the downloaded package into the directory, out of reach of 'g use'",
+				},
+				&cli.BoolFlag{
+					Name:  "skip-checksum",
+					Usage: "Skip checksum verification",
+				},
+				&cli.BoolFlag{
+					Name:  "non-interactive",
+					Usage: "Never prompt: download the first package and refuse packages without checksum",
+				},
+				&cli.BoolFlag{
+					Name:  "verify-signature",
+					Usage: "Refuse packages without a valid OpenPGP signature",
+				},
+				&cli.StringFlag{
+					Name:    "keyring",
+					Usage:   "OpenPGP keyring used to verify signatures, defaults to the embedded Go release signing key",
+					EnvVars: []string{keyringEnv},
+				},
 			},
 		},
 		{
 			Name:      "uninstall",
 			Usage:     "Uninstall a version",
 			UsageText: "g uninstall <version>",
-			Action:    uninstall,
+			Action:    withLock(uninstall),
 		},
 		{
 			Name:      "update",
@@ -98,11 +249,180 @@ var (
 			Action:    selfUpdate,
 			Hidden:    true,
 		},
+		{
+			Name:  "alias",
+			Usage: "Manage the names of versions",
+			Subcommands: []*cli.Command{
+				{
+					Name:      "set",
+					Usage:     "Name a version or a version constraint",
+					UsageText: "g alias set <name> <version>",
+					Action:    withLock(setAlias),
+				},
+				{
+					Name:      "ls",
+					Usage:     "List the aliases",
+					UsageText: "g alias ls",
+					Action:    listAliases,
+					Flags: []cli.Flag{
+						&cli.StringFlag{
+							Name:    "output",
+							Aliases: []string{"o"},
+							Usage:   "Output format. One of: [text|json]",
+						},
+					},
+					Before: func(ctx *cli.Context) error {
+						return validateLsFlag(ctx)
+					},
+				},
+				{
+					Name:      "rm",
+					Usage:     "Remove an alias",
+					UsageText: "g alias rm <name>",
+					Action:    withLock(removeAlias),
+				},
+			},
+		},
+		{
+			Name:      "audit",
+			Usage:     "Report known vulnerabilities of the standard library in installed versions",
+			UsageText: "g audit [version...] [--db <url|path>]",
+			Action:    audit,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    "db",
+					Usage:   "OSV vulnerability database, either a URL or a local path",
+					Value:   vulndb.DefaultURL,
+					EnvVars: []string{govulndbEnv},
+				},
+				&cli.StringFlag{
+					Name:    "output",
+					Aliases: []string{"o"},
+					Usage:   "Output format. One of: [text|json]",
+				},
+			},
+			Before: func(ctx *cli.Context) error {
+				return validateLsFlag(ctx)
+			},
+		},
+		{
+			Name:      "verify",
+			Usage:     "Detect modified, missing and extra files of installed versions",
+			UsageText: "g verify [version|--all] [--repair]",
+			Action:    withLock(verify),
+			Flags: []cli.Flag{
+				&cli.BoolFlag{
+					Name:  "all",
+					Usage: "Verify all installed versions",
+				},
+				&cli.BoolFlag{
+					Name:  "repair",
+					Usage: "Re-extract versions that failed verification from the cached or re-downloaded package",
+				},
+				&cli.StringFlag{
+					Name:    "output",
+					Aliases: []string{"o"},
+					Usage:   "Output format. One of: [text|json]",
+				},
+			},
+			Before: func(ctx *cli.Context) error {
+				return validateLsFlag(ctx)
+			},
+		},
 		{
 			Name:      "clean",
 			Usage:     "Remove files from the package download directory",
 			UsageText: "g clean",
-			Action:    clean,
+			Action:    withLock(clean),
+		},
+		{
+			Name:      "hook",
+			Usage:     "Print the shell hook switching the version of the session on directory change",
+			UsageText: "g hook [--install] bash|zsh|fish|pwsh",
+			Action:    hook,
+			Flags: []cli.Flag{
+				&cli.BoolFlag{
+					Name:    "install",
+					Usage:   "Install the version selected by the project files if it is missing",
+					EnvVars: []string{autoInstallEnv},
+				},
+			},
+			Before: validateShell,
+		},
+		{
+			Name:      "hook-env",
+			Usage:     "Print the statements activating the version selected by the project files in the session",
+			UsageText: "g hook-env [--install] bash|zsh|fish|pwsh",
+			Action:    hookEnv,
+			Flags: []cli.Flag{
+				&cli.BoolFlag{
+					Name:    "install",
+					Usage:   "Install the version selected by the project files if it is missing",
+					EnvVars: []string{autoInstallEnv},
+				},
+			},
+			Before: validateShell,
+			Hidden: true,
+		},
+		{
+			Name:      "exec",
+			Usage:     "Run a command with an installed version without switching to it",
+			UsageText: "g exec [--install] <version> -- <command> [arguments...]",
+			Action:    execCmd,
+			Flags: []cli.Flag{
+				&cli.BoolFlag{
+					Name:    "install",
+					Usage:   "Install the version if it is missing",
+					EnvVars: []string{autoInstallEnv},
+				},
+			},
+		},
+		{
+			Name:      "shell",
+			Usage:     "Print the statements switching the version of the current shell session only",
+			UsageText: "g shell [--shell bash|zsh|fish|pwsh|cmd] [--install] <version|--unset>",
+			Action:    shell,
+			Flags: []cli.Flag{
+				&cli.BoolFlag{
+					Name:    "install",
+					Usage:   "Install the version if it is missing",
+					EnvVars: []string{autoInstallEnv},
+				},
+				&cli.StringFlag{
+					Name:  "shell",
+					Usage: "Shell of the statements. One of: [bash|zsh|fish|pwsh|cmd] (detected by default)",
+				},
+				&cli.BoolFlag{
+					Name:  "unset",
+					Usage: "Restore the version of the session",
+				},
+			},
+		},
+		{
+			Name:  "shim",
+			Usage: "Manage the go and gofmt launchers resolving the version per invocation",
+			Subcommands: []*cli.Command{
+				{
+					Name:      "enable",
+					Usage:     "Write the go and gofmt launchers into the bin directory of g",
+					UsageText: "g shim enable",
+					Action:    withLock(enableShims),
+				},
+				{
+					Name:      "disable",
+					Usage:     "Remove the go and gofmt launchers",
+					UsageText: "g shim disable",
+					Action:    withLock(disableShims),
+				},
+			},
+		},
+		{
+			Name:            "shim-exec",
+			Usage:           "Run the tool of the version selected for the working directory",
+			UsageText:       "g shim-exec go|gofmt [arguments...]",
+			Action:          shimExec,
+			SkipFlagParsing: true,
+			Hidden:          true,
 		},
 		{
 			Name:      "env",
@@ -128,6 +448,42 @@ var (
 				},
 			},
 		},
+		{
+			Name:  "policy",
+			Usage: "Inspect the installation policy",
+			Subcommands: []*cli.Command{
+				{
+					Name:      "check",
+					Usage:     "Check the mirrors and the version (the one in use by default) against the policy",
+					UsageText: "g policy check [version]",
+					Action:    checkPolicy,
+				},
+			},
+		},
+		{
+			Name:  "checksums",
+			Usage: "Manage the official checksums of Go packages",
+			Subcommands: []*cli.Command{
+				{
+					Name:      "update",
+					Usage:     "Refresh the official checksums from the Go download page",
+					UsageText: "g checksums update [--url <download-page-url>] [--output <file>]",
+					Action:    withLock(updateChecksums),
+					Flags: []cli.Flag{
+						&cli.StringFlag{
+							Name:  "url",
+							Usage: "Official download page to collect checksums from (go.dev, golang.org or golang.google.cn)",
+							Value: collector.OfficialDownloadPageURL,
+						},
+						&cli.StringFlag{
+							Name:    "output",
+							Aliases: []string{"o"},
+							Usage:   "File to write the checksums to, defaults to the checksums file in the g home",
+						},
+					},
+				},
+			},
+		},
 		{
 			Name:      "mcp",
 			Usage:     "Run in mcp server mode",
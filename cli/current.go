@@ -0,0 +1,112 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+	"github.com/voidint/g/pkg/errs"
+	"github.com/voidint/g/pkg/project"
+)
+
+// resolution is the version selected for a directory and what decided it.
+type resolution struct {
+	// Version is the installed version selected, or the version requested if none is installed.
+	Version   string `json:"version"`
+	Source    string `json:"source"`
+	Installed bool   `json:"installed"`
+}
+
+// resolveVersion selects the version for the directory from the G_GO_VERSION environment variable, the project files,
+// or else the global default set by 'g use'. It returns nil if no version is selected.
+func resolveVersion(dir string) (*resolution, error) {
+	if vname := os.Getenv(goVersionEnv); vname != "" {
+		versions, err := listLocalVersions(versionsDir)
+		if err != nil {
+			return nil, err
+		}
+		r := resolution{Version: vname, Source: fmt.Sprintf("the %s environment variable", goVersionEnv)}
+		if target, _ := matchLocalVersion(versions, vname); target != "" {
+			r.Version, r.Installed = target, true
+		}
+		return &r, nil
+	}
+
+	sel, err := project.Select(dir)
+	if err != nil && !errors.Is(err, errs.ErrProjectFileNotFound) {
+		return nil, err
+	}
+	if sel != nil {
+		versions, err := listLocalVersions(versionsDir)
+		if err != nil {
+			return nil, err
+		}
+		r := resolution{Version: sel.Version, Source: describeSelection(sel)}
+		if target := matchSelection(versions, sel, inuse(goroot)); target != "" {
+			r.Version, r.Installed = target, true
+		}
+		return &r, nil
+	}
+
+	if vname := inuse(goroot); vname != "" && vname != "." {
+		return &resolution{Version: vname, Source: fmt.Sprintf("global default %q", goroot), Installed: true}, nil
+	}
+	return nil, nil
+}
+
+func current(ctx *cli.Context) (err error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+	r, err := resolveVersion(wd)
+	if err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+	if r == nil {
+		return cli.Exit(wrapstring("No version in use"), 1)
+	}
+
+	if ctx.String("output") == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "    ")
+		_ = enc.Encode(r)
+	} else {
+		printResolution(os.Stdout, r)
+	}
+
+	if !r.Installed {
+		return cli.Exit("", 1)
+	}
+	return nil
+}
+
+func printResolution(out io.Writer, r *resolution) {
+	if r.Installed {
+		_, _ = fmt.Fprintf(out, "%s (set by %s)\n", r.Version, r.Source)
+		return
+	}
+	_, _ = fmt.Fprintf(out, "%s (set by %s, not installed)\n", r.Version, r.Source)
+}
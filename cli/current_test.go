@@ -0,0 +1,86 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_resolveVersion(t *testing.T) {
+	rootDir := t.TempDir()
+	goroot = filepath.Join(rootDir, "go")
+	versionsDir = filepath.Join(rootDir, "versions")
+	_ = os.MkdirAll(filepath.Join(versionsDir, "1.21.4"), 0755)
+	_ = os.MkdirAll(filepath.Join(versionsDir, "1.22.3"), 0755)
+	projectDir := t.TempDir()
+	t.Setenv("GOWORK", "off")
+
+	t.Run("没有选择任何版本", func(t *testing.T) {
+		r, err := resolveVersion(projectDir)
+		assert.Nil(t, err)
+		assert.Nil(t, r)
+	})
+
+	t.Run("使用全局默认版本", func(t *testing.T) {
+		assert.Nil(t, switchVersion("1.21.4"))
+		r, err := resolveVersion(projectDir)
+		assert.Nil(t, err)
+		assert.Equal(t, &resolution{Version: "1.21.4", Source: `global default "` + goroot + `"`, Installed: true}, r)
+	})
+
+	t.Run("使用版本文件", func(t *testing.T) {
+		filename := filepath.Join(projectDir, ".go-version")
+		assert.Nil(t, os.WriteFile(filename, []byte("1.22\n"), 0600))
+		r, err := resolveVersion(projectDir)
+		assert.Nil(t, err)
+		assert.Equal(t, &resolution{Version: "1.22.3", Source: `"` + filename + `"`, Installed: true}, r)
+	})
+
+	t.Run("版本未安装", func(t *testing.T) {
+		filename := filepath.Join(projectDir, ".go-version")
+		assert.Nil(t, os.WriteFile(filename, []byte("1.20.14\n"), 0600))
+		r, err := resolveVersion(projectDir)
+		assert.Nil(t, err)
+		assert.False(t, r.Installed)
+
+		var buf bytes.Buffer
+		printResolution(&buf, r)
+		assert.Equal(t, `1.20.14 (set by "`+filename+`", not installed)`+"\n", buf.String())
+	})
+}
+
+func Test_resolveVersion_env(t *testing.T) {
+	rootDir := t.TempDir()
+	goroot = filepath.Join(rootDir, "go")
+	versionsDir = filepath.Join(rootDir, "versions")
+	_ = os.MkdirAll(filepath.Join(versionsDir, "1.21.4"), 0755)
+	projectDir := t.TempDir()
+	assert.Nil(t, os.WriteFile(filepath.Join(projectDir, ".go-version"), []byte("1.22.3\n"), 0600))
+
+	t.Setenv("G_GO_VERSION", "1.21")
+	r, err := resolveVersion(projectDir)
+	assert.Nil(t, err)
+	assert.Equal(t, &resolution{Version: "1.21.4", Source: "the G_GO_VERSION environment variable", Installed: true}, r)
+}
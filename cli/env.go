@@ -31,6 +31,13 @@ var envNames = []string{
 	homeEnv,
 	mirrorEnv,
 	experimentalEnv,
+	lockTimeoutEnv,
+	keyringEnv,
+	gosumdbEnv,
+	goproxyEnv,
+	govulndbEnv,
+	goVersionEnv,
+	autoInstallEnv,
 }
 
 func showEnv(ctx *cli.Context) (err error) {
@@ -0,0 +1,103 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+	"github.com/voidint/g/pkg/errs"
+	"github.com/voidint/g/version"
+)
+
+// goToolchainEnv is the environment variable of the go command selecting the toolchain.
+const goToolchainEnv = "GOTOOLCHAIN"
+
+func execCmd(ctx *cli.Context) (err error) {
+	args := ctx.Args().Slice()
+	if len(args) > 1 && args[1] == "--" {
+		args = append(args[:1], args[2:]...)
+	}
+	if len(args) < 2 {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+
+	vname, err := resolveAlias(args[0])
+	if err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+	v, err := findInstalled(vname, ctx.Bool("install"))
+	if err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+
+	p, err := loadPolicy()
+	if err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+	if err = checkVersionPolicy(p, v.Name(), nil); err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+
+	env := setEnv(toolEnv(os.Environ(), filepath.Join(versionsDir, v.Name())), goToolchainEnv, "local")
+	// The command is looked up in the PATH of the version.
+	if err = os.Setenv("PATH", getEnv(env, "PATH")); err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+	path, err := exec.LookPath(args[1])
+	if err != nil {
+		return cli.Exit(errstring(err), 127)
+	}
+	if err = execve(path, args[1:], env); err != nil {
+		return cli.Exit(errstring(errors.Wrapf(err, "failed to run %q", path)), 126)
+	}
+	return nil
+}
+
+// findInstalled returns the installed version matching the version name or constraint, optionally installing it first.
+func findInstalled(vname string, install bool) (*version.Version, error) {
+	for i := 0; ; i++ {
+		items, err := listLocalVersions(versionsDir)
+		if err != nil {
+			return nil, err
+		}
+		opts, err := finderOptions(vname, false)
+		if err != nil {
+			return nil, err
+		}
+		v, err := version.NewFinder(items, append(opts, version.WithFinderInstalled())...).Find(vname)
+		if err == nil {
+			return v, nil
+		}
+		if !errs.IsVersionNotFound(err) {
+			return nil, err
+		}
+		if !install || i > 0 {
+			return nil, fmt.Errorf("the %q version is not installed.%s", vname, didYouMean(version.Suggest(vname, items)))
+		}
+		if err = installVersion(vname); err != nil {
+			return nil, err
+		}
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/urfave/cli/v2"
+	"github.com/voidint/g/version"
+)
+
+// explain prints how the version name was resolved, in JSON if the output format is json.
+func explain(ctx *cli.Context, ex *version.Explanation) {
+	if ctx.String("output") == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "    ")
+		_ = enc.Encode(ex)
+		return
+	}
+	printExplanation(os.Stdout, ex)
+}
+
+func printExplanation(out io.Writer, ex *version.Explanation) {
+	target := "among the installed versions"
+	if ex.Goos != "" {
+		target = fmt.Sprintf("for %s/%s (%s packages)", ex.Goos, ex.Goarch, ex.Kind)
+	}
+	if ex.Mirror != "" {
+		target += " from " + ex.Mirror
+	}
+	_, _ = fmt.Fprintf(out, "Resolving %q %s\n", ex.Query, target)
+
+	width := 0
+	for _, c := range ex.Candidates {
+		if len(c.Version) > width {
+			width = len(c.Version)
+		}
+	}
+	for _, c := range ex.Candidates {
+		_, _ = fmt.Fprintf(out, "  %-*s  %-10s  %s\n", width, c.Version, c.Result, c.Reason)
+	}
+
+	switch {
+	case ex.Error != "":
+		_, _ = fmt.Fprintf(out, "Not resolved: %s\n", ex.Error)
+	case ex.Selected != "" && ex.URL != "":
+		_, _ = fmt.Fprintf(out, "Selected %s (%s)\n", ex.Selected, ex.URL)
+	case ex.Selected != "":
+		_, _ = fmt.Fprintf(out, "Selected %s\n", ex.Selected)
+	}
+}
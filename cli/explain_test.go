@@ -0,0 +1,60 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/voidint/g/version"
+)
+
+func Test_printExplanation(t *testing.T) {
+	t.Run("远程版本", func(t *testing.T) {
+		var buf bytes.Buffer
+		printExplanation(&buf, &version.Explanation{
+			Query:  "~1.21",
+			Kind:   "Archive",
+			Goos:   "linux",
+			Goarch: "amd64",
+			Candidates: []version.Candidate{
+				{Version: "1.22.0", Result: version.CandidateRejected, Reason: `does not match constraint "~1.21"`},
+				{Version: "1.21.10", Result: version.CandidateSelected, Reason: `matches constraint "~1.21"`},
+			},
+			Selected: "1.21.10",
+			Mirror:   "official|https://go.dev/dl/",
+			URL:      "https://go.dev/dl/go1.21.10.linux-amd64.tar.gz",
+		})
+		assert.Equal(t, `Resolving "~1.21" for linux/amd64 (Archive packages) from official|https://go.dev/dl/
+  1.22.0   rejected    does not match constraint "~1.21"
+  1.21.10  selected    matches constraint "~1.21"
+Selected 1.21.10 (https://go.dev/dl/go1.21.10.linux-amd64.tar.gz)
+`, buf.String())
+	})
+
+	t.Run("已安装版本查找失败", func(t *testing.T) {
+		var buf bytes.Buffer
+		printExplanation(&buf, &version.Explanation{Query: "1.23", Error: `version not found "1.23" [linux,amd64]`})
+		assert.Equal(t, `Resolving "1.23" among the installed versions
+Not resolved: version not found "1.23" [linux,amd64]
+`, buf.String())
+	})
+}
@@ -0,0 +1,298 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+	"github.com/voidint/g/pkg/errs"
+	"github.com/voidint/g/pkg/project"
+	"github.com/voidint/g/version"
+)
+
+// Shells supported by the generated scripts.
+const (
+	bashShell = "bash"
+	zshShell  = "zsh"
+	fishShell = "fish"
+	pwshShell = "pwsh"
+	cmdShell  = "cmd"
+)
+
+const (
+	// hookGorootEnv records the version directory activated by the shell hook in the session.
+	hookGorootEnv = "_G_HOOK_GOROOT"
+	// hookPrevGorootEnv records the GOROOT of the session before the shell hook took over.
+	hookPrevGorootEnv = "_G_HOOK_PREV_GOROOT"
+)
+
+var hookScripts = map[string]string{
+	bashShell: `_g_hook() {
+  local previous_exit_status=$?
+  if [[ "${_G_HOOK_PWD:-}" != "$PWD" ]]; then
+    _G_HOOK_PWD="$PWD"
+    eval "$(%[1]s hook-env%[2]s bash)"
+  fi
+  return $previous_exit_status
+}
+if [[ ";${PROMPT_COMMAND[*]:-};" != *";_g_hook;"* ]]; then
+  PROMPT_COMMAND="_g_hook${PROMPT_COMMAND:+;$PROMPT_COMMAND}"
+fi
+`,
+	zshShell: `_g_hook() {
+  eval "$(%[1]s hook-env%[2]s zsh)"
+}
+typeset -ag chpwd_functions
+if (( ! ${chpwd_functions[(I)_g_hook]} )); then
+  chpwd_functions=(_g_hook $chpwd_functions)
+fi
+_g_hook
+`,
+	fishShell: `function _g_hook --on-variable PWD
+    %[1]s hook-env%[2]s fish | source
+end
+_g_hook
+`,
+	pwshShell: `function global:_g_hook {
+    & %[1]s hook-env%[2]s pwsh | Out-String | Invoke-Expression
+}
+$global:_g_prev_location_action = $ExecutionContext.SessionState.InvokeCommand.LocationChangedAction
+$ExecutionContext.SessionState.InvokeCommand.LocationChangedAction = {
+    if ($global:_g_prev_location_action) { & $global:_g_prev_location_action @args }
+    _g_hook
+}
+_g_hook
+`,
+}
+
+func validateShell(ctx *cli.Context) error {
+	sh := ctx.Args().First()
+	if _, ok := hookScripts[sh]; !ok {
+		return cli.Exit(wrapstring(fmt.Sprintf("Unsupported shell %q, one of: [bash|zsh|fish|pwsh]", sh)), 1)
+	}
+	return nil
+}
+
+func hook(ctx *cli.Context) (err error) {
+	sh := ctx.Args().First()
+	self, err := os.Executable()
+	if err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+	var flags string
+	if ctx.Bool("install") {
+		flags = " --install"
+	}
+	fmt.Printf(hookScripts[sh], quote(sh, self), flags)
+	return nil
+}
+
+// hookEnv prints the statements activating the version selected by the project files of the working directory in the session,
+// or restoring the session when leaving the project. Problems are reported to stderr only, so that the prompt keeps working.
+func hookEnv(ctx *cli.Context) error {
+	sh := ctx.Args().First()
+	var target string
+	if wd, err := os.Getwd(); err == nil {
+		if target, err = hookTarget(wd, ctx.Bool("install")); err != nil {
+			fmt.Fprintln(os.Stderr, errstring(err))
+		}
+	}
+	for _, stmt := range hookStatements(sh, target, os.Getenv) {
+		fmt.Println(stmt)
+	}
+	return nil
+}
+
+// hookTarget returns the directory of the installed version pinned by G_GO_VERSION or selected by the project files of the directory,
+// optionally installing it first. It returns an empty string if no version is selected, and an error if the policy denies the version.
+func hookTarget(dir string, install bool) (string, error) {
+	vname, err := hookVersion(dir, install)
+	if err != nil || vname == "" {
+		return "", err
+	}
+
+	p, err := loadPolicy()
+	if err != nil {
+		return "", err
+	}
+	// The hook runs on every prompt, so the rules needing the network are left to 'g use' and 'g install'.
+	if err = p.CheckLocalVersion(vname); err != nil {
+		return "", err
+	}
+	return filepath.Join(versionsDir, vname), nil
+}
+
+// hookVersion returns the installed version pinned by G_GO_VERSION or selected by the project files of the directory.
+func hookVersion(dir string, install bool) (string, error) {
+	if vname := os.Getenv(goVersionEnv); vname != "" {
+		// The version is pinned for the session, e.g. by 'g shell'.
+		v, err := findInstalled(vname, false)
+		if err != nil {
+			return "", err
+		}
+		return v.Name(), nil
+	}
+
+	sel, err := project.Select(dir)
+	if err != nil {
+		if errors.Is(err, errs.ErrProjectFileNotFound) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	vname, err := matchInstalled(sel.Version, install, func(versions []*version.Version) (string, error) {
+		return matchSelection(versions, sel, inuse(goroot)), nil
+	})
+	if err != nil {
+		return "", err
+	}
+	if vname == "" {
+		return "", fmt.Errorf("%s selects <%s>, which is not installed", describeSelection(sel), sel.Version)
+	}
+	return vname, nil
+}
+
+// installVersion installs the version without switching to it by running another g process, whose output goes to stderr.
+// The process never prompts: it picks the first package and refuses the packages without checksum.
+func installVersion(vname string) error {
+	self, err := os.Executable()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	fmt.Fprintf(os.Stderr, "[g] Installing <%s>...\n", vname)
+	cmd := exec.Command(self, "install", "--nouse", "--non-interactive", vname)
+	if lockHeld {
+		cmd.Env = append(os.Environ(), lockHeldEnv+"="+strconv.Itoa(os.Getpid()))
+	}
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	return errors.WithStack(cmd.Run())
+}
+
+// hookStatements returns the statements switching the session from the version directory activated before (if any) to the target one.
+// An empty target restores the GOROOT and PATH of the session.
+func hookStatements(sh, target string, getenv func(string) string) []string {
+	prev := getenv(hookGorootEnv)
+	if prev == target {
+		return nil
+	}
+
+	path := removePathEntry(getenv("PATH"), prev)
+	if target == "" {
+		stmts := []string{exportEnv(sh, "PATH", path)}
+		if gr := getenv(hookPrevGorootEnv); gr != "" {
+			stmts = append(stmts, exportEnv(sh, "GOROOT", gr))
+		} else {
+			stmts = append(stmts, unsetEnv(sh, "GOROOT"))
+		}
+		return append(stmts, unsetEnv(sh, hookGorootEnv), unsetEnv(sh, hookPrevGorootEnv))
+	}
+
+	var stmts []string
+	if prev == "" {
+		stmts = append(stmts, exportEnv(sh, hookPrevGorootEnv, getenv("GOROOT")))
+	}
+	return append(stmts,
+		exportEnv(sh, "GOROOT", target),
+		exportEnv(sh, "PATH", prependPathEntry(path, target)),
+		exportEnv(sh, hookGorootEnv, target),
+	)
+}
+
+// prependPathEntry puts the bin directory of the Go root in front of the PATH.
+func prependPathEntry(path, root string) string {
+	bin := filepath.Join(root, "bin")
+	if path == "" {
+		return bin
+	}
+	return bin + string(os.PathListSeparator) + path
+}
+
+// removePathEntry removes the bin directory of the Go root from the PATH.
+func removePathEntry(path, root string) string {
+	if root == "" {
+		return path
+	}
+	bin := filepath.Join(root, "bin")
+	entries := filepath.SplitList(path)
+	kept := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry != bin {
+			kept = append(kept, entry)
+		}
+	}
+	return strings.Join(kept, string(os.PathListSeparator))
+}
+
+// exportEnv returns the statement of the shell setting the environment variable.
+func exportEnv(sh, name, value string) string {
+	switch sh {
+	case fishShell:
+		if name == "PATH" {
+			// PATH is a list in fish.
+			entries := filepath.SplitList(value)
+			for i := range entries {
+				entries[i] = quote(sh, entries[i])
+			}
+			return fmt.Sprintf("set -gx PATH %s;", strings.Join(entries, " "))
+		}
+		return fmt.Sprintf("set -gx %s %s;", name, quote(sh, value))
+	case pwshShell:
+		return fmt.Sprintf("$env:%s = %s", name, quote(sh, value))
+	case cmdShell:
+		return fmt.Sprintf(`set "%s=%s"`, name, value)
+	default:
+		return fmt.Sprintf("export %s=%s;", name, quote(sh, value))
+	}
+}
+
+// unsetEnv returns the statement of the shell removing the environment variable.
+func unsetEnv(sh, name string) string {
+	switch sh {
+	case fishShell:
+		return fmt.Sprintf("set -e %s;", name)
+	case pwshShell:
+		return fmt.Sprintf("Remove-Item Env:%s -ErrorAction SilentlyContinue", name)
+	case cmdShell:
+		return fmt.Sprintf("set %s=", name)
+	default:
+		return fmt.Sprintf("unset %s;", name)
+	}
+}
+
+// quote returns the string as a single-quoted literal of the shell.
+func quote(sh, s string) string {
+	switch sh {
+	case fishShell:
+		return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+	case pwshShell:
+		return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+	default:
+		return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+	}
+}
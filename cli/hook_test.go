@@ -0,0 +1,128 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/voidint/g/pkg/errs"
+)
+
+func Test_quote(t *testing.T) {
+	assert.Equal(t, `'/a b/it'\''s'`, quote(bashShell, "/a b/it's"))
+	assert.Equal(t, `'C:\\a\\b\'s'`, quote(fishShell, `C:\a\b's`))
+	assert.Equal(t, `'C:\a ''b'''`, quote(pwshShell, `C:\a 'b'`))
+}
+
+func Test_hookStatements(t *testing.T) {
+	sep := string(os.PathListSeparator)
+	root1 := filepath.Join("/home", ".g", "versions", "1.21.4")
+	root2 := filepath.Join("/home", ".g", "versions", "1.22.3")
+	bin := func(root string) string { return filepath.Join(root, "bin") }
+
+	env := func(kv ...string) func(string) string {
+		m := make(map[string]string)
+		for i := 0; i < len(kv); i += 2 {
+			m[kv[i]] = kv[i+1]
+		}
+		return func(key string) string { return m[key] }
+	}
+
+	t.Run("进入项目目录", func(t *testing.T) {
+		stmts := hookStatements(bashShell, root1, env("PATH", "/usr/bin", "GOROOT", "/home/.g/go"))
+		assert.Equal(t, []string{
+			"export _G_HOOK_PREV_GOROOT='/home/.g/go';",
+			"export GOROOT='" + root1 + "';",
+			"export PATH='" + bin(root1) + sep + "/usr/bin';",
+			"export _G_HOOK_GOROOT='" + root1 + "';",
+		}, stmts)
+	})
+
+	t.Run("切换到另一个项目", func(t *testing.T) {
+		stmts := hookStatements(fishShell, root2, env("PATH", bin(root1)+sep+"/usr/bin", hookGorootEnv, root1))
+		assert.Equal(t, []string{
+			"set -gx GOROOT '" + root2 + "';",
+			"set -gx PATH '" + bin(root2) + "' '/usr/bin';",
+			"set -gx _G_HOOK_GOROOT '" + root2 + "';",
+		}, stmts)
+	})
+
+	t.Run("离开项目目录", func(t *testing.T) {
+		stmts := hookStatements(pwshShell, "", env("PATH", bin(root1)+sep+"/usr/bin", hookGorootEnv, root1, hookPrevGorootEnv, "/home/.g/go"))
+		assert.Equal(t, []string{
+			"$env:PATH = '/usr/bin'",
+			"$env:GOROOT = '/home/.g/go'",
+			"Remove-Item Env:_G_HOOK_GOROOT -ErrorAction SilentlyContinue",
+			"Remove-Item Env:_G_HOOK_PREV_GOROOT -ErrorAction SilentlyContinue",
+		}, stmts)
+
+		stmts = hookStatements(zshShell, "", env("PATH", bin(root1), hookGorootEnv, root1))
+		assert.Equal(t, "unset GOROOT;", stmts[1])
+	})
+
+	t.Run("版本未变化", func(t *testing.T) {
+		assert.Empty(t, hookStatements(bashShell, root1, env(hookGorootEnv, root1)))
+		assert.Empty(t, hookStatements(bashShell, "", env()))
+	})
+}
+
+func Test_hookTarget(t *testing.T) {
+	rootDir := t.TempDir()
+	ghomeDir = rootDir
+	goroot = filepath.Join(rootDir, "go")
+	versionsDir = filepath.Join(rootDir, "versions")
+	_ = os.MkdirAll(filepath.Join(versionsDir, "1.21.4"), 0755)
+	projectDir := t.TempDir()
+	t.Setenv("GOWORK", "off")
+
+	target, err := hookTarget(projectDir, false)
+	assert.Nil(t, err)
+	assert.Equal(t, "", target)
+
+	assert.Nil(t, os.WriteFile(filepath.Join(projectDir, ".go-version"), []byte("1.21.4\n"), 0600))
+	target, err = hookTarget(projectDir, false)
+	assert.Nil(t, err)
+	assert.Equal(t, filepath.Join(versionsDir, "1.21.4"), target)
+
+	assert.Nil(t, os.WriteFile(filepath.Join(ghomeDir, policyFile), []byte(`{"deny": ["1.21.4"]}`), 0600))
+	_, err = hookTarget(projectDir, false)
+	assert.True(t, errs.IsPolicyViolation(err))
+	t.Setenv(goVersionEnv, "1.21.4")
+	_, err = hookTarget(projectDir, false)
+	assert.True(t, errs.IsPolicyViolation(err))
+	assert.Nil(t, os.Unsetenv(goVersionEnv))
+
+	// The rules needing the network are not checked on every prompt.
+	t.Setenv(mirrorEnv, "official|http://127.0.0.1:1/")
+	assert.Nil(t, os.WriteFile(filepath.Join(ghomeDir, policyFile), []byte(`{"recent_minors": 1}`), 0600))
+	target, err = hookTarget(projectDir, false)
+	assert.Nil(t, err)
+	assert.Equal(t, filepath.Join(versionsDir, "1.21.4"), target)
+	assert.Nil(t, os.Remove(filepath.Join(ghomeDir, policyFile)))
+
+	assert.Nil(t, os.WriteFile(filepath.Join(projectDir, ".go-version"), []byte("1.22.3\n"), 0600))
+	_, err = hookTarget(projectDir, false)
+	assert.NotNil(t, err)
+	assert.True(t, strings.Contains(err.Error(), "not installed"))
+}
@@ -30,17 +30,57 @@ import (
 	ct "github.com/daviddengcn/go-colortext"
 	"github.com/dixonwille/wlog/v3"
 	"github.com/dixonwille/wmenu/v5"
-	"github.com/mholt/archiver/v3"
 	"github.com/pkg/errors"
 	"github.com/urfave/cli/v2"
 	"github.com/voidint/g/collector"
+	"github.com/voidint/g/pkg/archive"
+	"github.com/voidint/g/pkg/errs"
+	"github.com/voidint/g/pkg/fsutil"
+	"github.com/voidint/g/pkg/manifest"
+	"github.com/voidint/g/pkg/policy"
+	"github.com/voidint/g/pkg/signature"
 	"github.com/voidint/g/version"
 )
 
 func install(ctx *cli.Context) (err error) {
 	vname := ctx.Args().First()
 	if vname == "" {
-		return cli.ShowSubcommandHelp(ctx)
+		// Installs the version selected by the project files if version is omitted
+		sel, err := selectProjectVersion()
+		if err != nil {
+			if errors.Is(err, errs.ErrProjectFileNotFound) {
+				return cli.ShowSubcommandHelp(ctx)
+			}
+			return cli.Exit(errstring(err), 1)
+		}
+		if !ctx.Bool("explain") {
+			fmt.Printf("Found %s <%s>\n", describeSelection(sel), sel.Version)
+		}
+		vname = sel.Version
+	} else if vname, err = resolveAlias(vname); err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+
+	goos, goarch, err := targetPlatform(ctx)
+	if err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+	downloadOnly := ctx.Command.Name == "download" || ctx.Bool("download-only") || ctx.String("extract-to") != ""
+	if !downloadOnly && (goos != runtime.GOOS || goarch != runtime.GOARCH) {
+		return cli.Exit(wrapstring(fmt.Sprintf("Packages for %s/%s cannot be installed on %s/%s, use --download-only.", goos, goarch, runtime.GOOS, runtime.GOARCH)), 1)
+	}
+	if downloadOnly && ctx.Bool("sumdb") {
+		return cli.Exit(wrapstring("The --sumdb flag cannot be used to download packages only."), 1)
+	}
+
+	cleanStaging()
+
+	p, err := loadPolicy()
+	if err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+	if err = p.CheckMirrors(mirrors()); err != nil {
+		return cli.Exit(errstring(err), 1)
 	}
 
 	// Find matching Go version.
@@ -53,31 +93,66 @@ func install(ctx *cli.Context) (err error) {
 		return cli.Exit(errstring(err), 1)
 	}
 
-	v, err := version.NewFinder(items,
+	opts, err := finderOptions(vname, ctx.Bool("prerelease"))
+	if err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+	fdr := version.NewFinder(items, append(opts,
 		version.WithFinderPackageKind(version.ArchiveKind),
-		version.WithFinderGoos(runtime.GOOS),
-		version.WithFinderGoarch(runtime.GOARCH),
-	).Find(vname)
+		version.WithFinderGoos(goos),
+		version.WithFinderGoarch(goarch),
+	)...)
+	if ctx.Bool("explain") {
+		v, ex, err := fdr.Explain(vname)
+		ex.Mirror = c.Name() + "|" + c.URL()
+		if err == nil {
+			if pkgs, err := v.FindPackages(version.ArchiveKind, goos, goarch); err == nil {
+				ex.URL = pkgs[0].URL
+			}
+		}
+		explain(ctx, ex)
+		if err != nil {
+			return cli.Exit("", 1)
+		}
+		return nil
+	}
+	v, err := fdr.Find(vname)
 	if err != nil {
 		return cli.Exit(errstring(err), 1)
 	}
 
 	vname = v.Name()
+	if err = checkVersionPolicy(p, vname, c); err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
 	targetV := filepath.Join(versionsDir, vname)
 
 	// Check if the version is already installed.
 	var finfo os.FileInfo
-	if finfo, err = os.Stat(targetV); err == nil && finfo.IsDir() {
+	if finfo, err = os.Stat(targetV); err == nil && finfo.IsDir() && !downloadOnly {
 		return cli.Exit(fmt.Sprintf("[g] %q version has been installed.", vname), 1)
 	}
 
-	// Find installation packages for current platform
-	pkgs, err := v.FindPackages(version.ArchiveKind, runtime.GOOS, runtime.GOARCH)
+	requireSigFile, requireSig := p.RequireSignature()
+	if ctx.Bool("sumdb") {
+		if requireSig {
+			return cli.Exit(errstring(errs.NewPolicyViolationError(requireSigFile, policy.RuleRequireSignature, vname,
+				"toolchain modules are not signed, they are verified by the checksum database instead")), 1)
+		}
+		// The toolchain module authenticated by the checksum database takes the place of the package from the mirror.
+		if err = installToolchainModule(vname); err != nil {
+			return cli.Exit(errstring(err), 1)
+		}
+		return activate(ctx, vname)
+	}
+
+	// Find installation packages for the target platform
+	pkgs, err := v.FindPackages(version.ArchiveKind, goos, goarch)
 	if err != nil {
 		return cli.Exit(errstring(err), 1)
 	}
 	var pkg version.Package
-	if len(pkgs) > 1 {
+	if len(pkgs) > 1 && !ctx.Bool("non-interactive") {
 		menu := wmenu.NewMenu("Please select the package you want to install.")
 		menu.AddColor(
 			wlog.Color{Code: ct.Green},
@@ -104,10 +179,27 @@ func install(ctx *cli.Context) (err error) {
 	}
 
 	skipChecksum := ctx.Bool("skip-checksum")
+	requireChecksumFile, requireChecksum := p.RequireChecksum()
+	if skipChecksum && requireChecksum {
+		return cli.Exit(errstring(errs.NewPolicyViolationError(requireChecksumFile, policy.RuleRequireChecksum, vname,
+			"checksum verification cannot be skipped")), 1)
+	}
 
 	if !skipChecksum {
+		if err = loadChecksumDB(); err != nil {
+			return cli.Exit(errstring(err), 1)
+		}
+
 		var checksumNotFound bool
-		if pkg.Checksum == "" && pkg.ChecksumURL == "" {
+		hasChecksum := pkg.HasChecksum()
+		if !hasChecksum && requireChecksum {
+			return cli.Exit(errstring(errs.NewPolicyViolationError(requireChecksumFile, policy.RuleRequireChecksum, vname,
+				fmt.Sprintf("no checksum found for %s", pkg.FileName))), 1)
+		}
+		if !hasChecksum && ctx.Bool("non-interactive") {
+			return cli.Exit(wrapstring(fmt.Sprintf("Checksum file not found for %s, use --skip-checksum to install it anyway.", pkg.FileName)), 1)
+		}
+		if !hasChecksum {
 			checksumNotFound = true
 			menu := wmenu.NewMenu("Checksum file not found, do you want to continue?")
 			menu.IsYesNo(wmenu.DefN)
@@ -124,13 +216,10 @@ func install(ctx *cli.Context) (err error) {
 		}
 	}
 
-	var ext string
-	if runtime.GOOS == "windows" {
-		ext = "zip"
-	} else {
-		ext = "tar.gz"
+	filename, err := packageFile(&pkg)
+	if err != nil {
+		return cli.Exit(errstring(err), 1)
 	}
-	filename := filepath.Join(downloadsDir, fmt.Sprintf("go%s.%s-%s.%s", vname, runtime.GOOS, runtime.GOARCH, ext))
 
 	if _, err = os.Stat(filename); os.IsNotExist(err) {
 		// Download package remotely and verify checksum.
@@ -141,6 +230,7 @@ func install(ctx *cli.Context) (err error) {
 		if !skipChecksum {
 			fmt.Println("Computing checksum with", pkg.Algorithm)
 			if err = pkg.VerifyChecksum(filename); err != nil {
+				_ = os.Remove(filename)
 				return cli.Exit(errstring(err), 1)
 			}
 			fmt.Println("Checksums matched")
@@ -158,37 +248,230 @@ func install(ctx *cli.Context) (err error) {
 		}
 	}
 
-	// Clean up legacy files.
-	_ = os.RemoveAll(filepath.Join(versionsDir, "go"))
+	if ctx.Bool("verify-signature") || requireSig {
+		if err = verifySignature(&pkg, filename, ctx.String("keyring")); err != nil {
+			_ = os.Remove(filename)
+			return cli.Exit(errstring(err), 1)
+		}
+	}
 
-	// Extract installation archive.
-	if err = archiver.Unarchive(filename, versionsDir); err != nil {
-		return cli.Exit(errstring(err), 1)
+	if downloadOnly {
+		fmt.Printf("Downloaded %s\n", filename)
+		if dir := ctx.String("extract-to"); dir != "" {
+			if dir, err = extractPackage(filename, dir, &pkg); err != nil {
+				return cli.Exit(errstring(err), 1)
+			}
+			fmt.Printf("Extracted to %s\n", dir)
+		}
+		return nil
 	}
-	// Rename version directory.
-	if err = os.Rename(filepath.Join(versionsDir, "go"), targetV); err != nil {
+
+	// Extract installation archive.
+	if err = installArchive(filename, vname); err != nil {
 		return cli.Exit(errstring(err), 1)
 	}
+	return activate(ctx, vname)
+}
 
+// activate switches to the newly installed version unless the user asked not to.
+func activate(ctx *cli.Context, vname string) error {
 	if ctx.Bool("nouse") {
 		return nil
 	}
 
-	if err = switchVersion(vname); err != nil {
+	if err := switchVersion(vname); err != nil {
 		return cli.Exit(errstring(err), 1)
 	}
 	return nil
 }
 
-func switchVersion(vname string) error {
+// verifySignature checks the package against its detached OpenPGP signature.
+func verifySignature(pkg *version.Package, filename, keyringFile string) (err error) {
+	verifier, err := signature.NewVerifier(keyringFile)
+	if err != nil {
+		return err
+	}
+	fmt.Println("Verifying signature")
+	if err = pkg.VerifySignature(filename, verifier); err != nil {
+		return err
+	}
+	fmt.Println("Signature verified")
+	return nil
+}
+
+// targetPlatform returns the GOOS and GOARCH of the packages to install, those of the current platform unless the --os and --arch flags say otherwise.
+// The flags also accept the labels of the download page, e.g. 'macOS' or 'x86-64'.
+func targetPlatform(ctx *cli.Context) (goos, goarch string, err error) {
+	goos, goarch = runtime.GOOS, runtime.GOARCH
+	if name := ctx.String("os"); name != "" {
+		if goos = version.CanonicalOS(name); goos == "" {
+			return "", "", fmt.Errorf("unknown operating system %q", name)
+		}
+	}
+	if name := ctx.String("arch"); name != "" {
+		if goarch = version.CanonicalArch(name); goarch == "" {
+			return "", "", fmt.Errorf("unknown architecture %q", name)
+		}
+	}
+	return goos, goarch, nil
+}
+
+// packageFile returns the path of the cached package. The file name comes from the mirror,
+// so anything but a plain file name (e.g. one holding '../') is refused.
+func packageFile(pkg *version.Package) (string, error) {
+	if err := checkPackageName(pkg.FileName); err != nil {
+		return "", err
+	}
+	return filepath.Join(downloadsDir, pkg.FileName), nil
+}
+
+// checkPackageName rejects the package file names that are not plain file names.
+func checkPackageName(name string) error {
+	if name == "" || name == "." || name == ".." || filepath.Base(name) != name || strings.ContainsAny(name, `/\`) {
+		return errors.Wrapf(errs.ErrUnsafePackageName, "%q", name)
+	}
+	return nil
+}
+
+// cachedPackage returns the path of the cached archive package of the version for the platform.
+func cachedPackage(vname, goos, goarch string) (filename string, ok bool) {
+	entries, err := os.ReadDir(downloadsDir)
+	if err != nil {
+		return "", false
+	}
+	prefix := fmt.Sprintf("go%s.%s-", vname, goos)
+	for i := range entries {
+		name := entries[i].Name()
+		if !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ".tar.gz") && !strings.HasSuffix(name, ".zip") {
+			continue
+		}
+		if pgoos, pgoarch := version.ParsePlatform(name); pgoos == goos && pgoarch == goarch {
+			return filepath.Join(downloadsDir, name), true
+		}
+	}
+	return "", false
+}
+
+// extractPackage unpacks the package into the directory as a Go distribution named after the package,
+// e.g. '<dir>/go1.22.3.linux-arm64', which is out of reach of 'g use'. It returns the path of the distribution.
+func extractPackage(filename, dir string, pkg *version.Package) (targetDir string, err error) {
+	name := strings.TrimSuffix(strings.TrimSuffix(pkg.FileName, ".tar.gz"), ".zip")
+	if err = checkPackageName(name); err != nil {
+		return "", err
+	}
+	targetDir = filepath.Join(dir, name)
+	if _, err = os.Stat(targetDir); err == nil {
+		return "", fmt.Errorf("%q already exists", targetDir)
+	}
+	if err = os.MkdirAll(dir, 0755); err != nil {
+		return "", errors.WithStack(err)
+	}
+	cleanStagingIn(dir)
+
+	stagingDir, err := os.MkdirTemp(dir, stagingPrefix)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	defer os.RemoveAll(stagingDir)
+
+	rootDir, err := archive.Extract(filename, stagingDir)
+	if err != nil {
+		return "", err
+	}
+	if err = os.Rename(rootDir, targetDir); err != nil {
+		return "", errors.WithStack(err)
+	}
+	return targetDir, nil
+}
+
+// stagingPrefix is the name prefix of the temporary directories that installations are extracted into.
+const stagingPrefix = ".staging-"
+
+// installArchive extracts the package into a staging directory inside versionsDir
+// and atomically renames it into place once everything has been written to disk.
+func installArchive(filename, vname string) (err error) {
+	return installWith(filename, vname, archive.Extract)
+}
+
+// installWith installs the version from the package with the extract function, which returns the root directory of the Go distribution.
+func installWith(filename, vname string, extract func(filename, dstDir string) (rootDir string, err error)) (err error) {
+	size, err := archive.UncompressedSize(filename)
+	if err != nil {
+		return err
+	}
+	if err = fsutil.EnsureFreeSpace(versionsDir, size); err != nil {
+		return err
+	}
+
+	stagingDir, err := os.MkdirTemp(versionsDir, stagingPrefix)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer os.RemoveAll(stagingDir)
+
+	rootDir, err := extract(filename, stagingDir)
+	if err != nil {
+		return err
+	}
+	if err = fsutil.SyncTree(rootDir); err != nil {
+		return errors.WithStack(err)
+	}
+	m, err := manifest.Build(rootDir)
+	if err != nil {
+		return err
+	}
+
+	// An existing installation (e.g. one being repaired) is moved aside and removed along with the staging directory.
 	targetV := filepath.Join(versionsDir, vname)
+	oldV := filepath.Join(stagingDir, "old")
+	if err = os.Rename(targetV, oldV); err != nil && !os.IsNotExist(err) {
+		return errors.WithStack(err)
+	}
+	if err = os.Rename(rootDir, targetV); err != nil {
+		_ = os.Rename(oldV, targetV)
+		return errors.WithStack(err)
+	}
+	if err = m.Save(manifestFile(vname)); err != nil {
+		return err
+	}
+	return fsutil.SyncDir(versionsDir)
+}
+
+// cleanStaging removes staging directories left behind by interrupted installations.
+func cleanStaging() {
+	cleanStagingIn(versionsDir)
+}
+
+// cleanStagingIn removes staging directories left behind in the directory by interrupted installations or extractions.
+func cleanStagingIn(dir string) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for i := range entries {
+		if entries[i].IsDir() && strings.HasPrefix(entries[i].Name(), stagingPrefix) {
+			_ = os.RemoveAll(filepath.Join(dir, entries[i].Name()))
+		}
+	}
+}
 
-	// Recreate symbolic link
-	_ = os.Remove(goroot)
+func switchVersion(vname string) error {
+	targetV := filepath.Join(versionsDir, vname)
 
-	if err := mkSymlink(targetV, goroot); err != nil {
+	// Create the new symbolic link aside and rename it over the old one, so that GOROOT never disappears.
+	tmpLink := fmt.Sprintf("%s.%d.tmp", goroot, os.Getpid())
+	_ = os.Remove(tmpLink)
+	if err := mkSymlink(targetV, tmpLink); err != nil {
 		return errors.WithStack(err)
 	}
+	if err := os.Rename(tmpLink, goroot); err != nil {
+		// Directory junctions on Windows cannot be replaced in place.
+		_ = os.Remove(goroot)
+		if err = os.Rename(tmpLink, goroot); err != nil {
+			_ = os.Remove(tmpLink)
+			return errors.WithStack(err)
+		}
+	}
 	if output, err := exec.Command(filepath.Join(goroot, "bin", "go"), "version").Output(); err == nil {
 		fmt.Printf("Now using %s", strings.TrimPrefix(string(output), "go version "))
 	}
@@ -0,0 +1,249 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package cli
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"flag"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli/v2"
+	"github.com/voidint/g/pkg/errs"
+	"github.com/voidint/g/version"
+)
+
+// writeGoArchive creates a minimal Go distribution archive containing the specified files.
+func writeGoArchive(t *testing.T, files map[string]string) string {
+	filename := filepath.Join(t.TempDir(), "go.tar.gz")
+	f, err := os.Create(filename)
+	assert.Nil(t, err)
+	defer f.Close()
+
+	gw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gw)
+	for name, body := range files {
+		assert.Nil(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0755, Size: int64(len(body)), Typeflag: tar.TypeReg}))
+		_, err = tw.Write([]byte(body))
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, tw.Close())
+	assert.Nil(t, gw.Close())
+	return filename
+}
+
+func Test_installArchive(t *testing.T) {
+	versionsDir = t.TempDir()
+
+	t.Run("安装成功且不残留临时目录", func(t *testing.T) {
+		assert.Nil(t, installArchive(writeGoArchive(t, map[string]string{"go/bin/go": "#!/bin/sh"}), "1.21.4"))
+
+		data, err := os.ReadFile(filepath.Join(versionsDir, "1.21.4", "bin", "go"))
+		assert.Nil(t, err)
+		assert.Equal(t, "#!/bin/sh", string(data))
+
+		_, err = os.Stat(manifestFile("1.21.4"))
+		assert.Nil(t, err)
+
+		entries, err := os.ReadDir(versionsDir)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(entries)) // the version and the manifests
+	})
+
+	t.Run("安装失败不产生版本目录", func(t *testing.T) {
+		err := installArchive(writeGoArchive(t, map[string]string{"go/VERSION": "x", "evil/VERSION": "x"}), "1.20.14")
+		assert.NotNil(t, err)
+
+		_, err = os.Stat(filepath.Join(versionsDir, "1.20.14"))
+		assert.True(t, os.IsNotExist(err))
+
+		entries, err := os.ReadDir(versionsDir)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(entries))
+	})
+}
+
+func Test_cleanStaging(t *testing.T) {
+	versionsDir = t.TempDir()
+	_ = os.MkdirAll(filepath.Join(versionsDir, stagingPrefix+"123", "go", "bin"), 0755)
+	_ = os.MkdirAll(filepath.Join(versionsDir, "1.21.4"), 0755)
+
+	cleanStaging()
+
+	entries, err := os.ReadDir(versionsDir)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(entries))
+	assert.Equal(t, "1.21.4", entries[0].Name())
+
+	t.Run("--extract-to目录", func(t *testing.T) {
+		dir := t.TempDir()
+		_ = os.MkdirAll(filepath.Join(dir, stagingPrefix+"456", "go", "bin"), 0755)
+		_ = os.MkdirAll(filepath.Join(dir, "go1.21.4.linux-arm64"), 0755)
+
+		filename := writeGoArchive(t, map[string]string{"go/VERSION": "go1.99.0"})
+		_, err := extractPackage(filename, dir, &version.Package{FileName: "go1.99.0.linux-arm64.tar.gz"})
+		assert.Nil(t, err)
+
+		entries, err := os.ReadDir(dir)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(entries))
+		assert.Equal(t, "go1.21.4.linux-arm64", entries[0].Name())
+		assert.Equal(t, "go1.99.0.linux-arm64", entries[1].Name())
+	})
+}
+
+func Test_switchVersion(t *testing.T) {
+	rootDir := t.TempDir()
+	goroot = filepath.Join(rootDir, "go")
+	versionsDir = filepath.Join(rootDir, "versions")
+	_ = os.MkdirAll(filepath.Join(versionsDir, "1.20.14"), 0755)
+	_ = os.MkdirAll(filepath.Join(versionsDir, "1.21.4"), 0755)
+
+	t.Run("首次切换版本", func(t *testing.T) {
+		assert.Nil(t, switchVersion("1.20.14"))
+		assert.Equal(t, "1.20.14", inuse(goroot))
+	})
+
+	t.Run("替换已有的符号链接", func(t *testing.T) {
+		assert.Nil(t, switchVersion("1.21.4"))
+		assert.Equal(t, "1.21.4", inuse(goroot))
+
+		entries, err := os.ReadDir(rootDir)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(entries)) // no temporary link left behind
+	})
+}
+
+func Test_targetPlatform(t *testing.T) {
+	newContext := func(args ...string) *cli.Context {
+		set := flag.NewFlagSet("install", flag.ContinueOnError)
+		set.String("os", "", "")
+		set.String("arch", "", "")
+		assert.Nil(t, set.Parse(args))
+		return cli.NewContext(nil, set, nil)
+	}
+
+	t.Run("默认为当前平台", func(t *testing.T) {
+		goos, goarch, err := targetPlatform(newContext())
+		assert.Nil(t, err)
+		assert.Equal(t, runtime.GOOS, goos)
+		assert.Equal(t, runtime.GOARCH, goarch)
+	})
+
+	t.Run("指定其他平台", func(t *testing.T) {
+		goos, goarch, err := targetPlatform(newContext("--os", "linux", "--arch", "arm64"))
+		assert.Nil(t, err)
+		assert.Equal(t, "linux", goos)
+		assert.Equal(t, "arm64", goarch)
+	})
+
+	t.Run("下载页面的标签", func(t *testing.T) {
+		goos, goarch, err := targetPlatform(newContext("--os", "macOS", "--arch", "x86-64"))
+		assert.Nil(t, err)
+		assert.Equal(t, "darwin", goos)
+		assert.Equal(t, "amd64", goarch)
+	})
+
+	t.Run("未知的平台", func(t *testing.T) {
+		_, _, err := targetPlatform(newContext("--os", "beos"))
+		assert.NotNil(t, err)
+		_, _, err = targetPlatform(newContext("--arch", "sparc"))
+		assert.NotNil(t, err)
+	})
+}
+
+func Test_cachedPackage(t *testing.T) {
+	saved := downloadsDir
+	defer func() { downloadsDir = saved }()
+	downloadsDir = t.TempDir()
+	for _, name := range []string{"go1.21.4.linux-armv6l.tar.gz", "go1.21.4.linux-arm64.tar.gz", "go1.21.4.linux-arm64.tar.gz.asc", "go1.21.40.linux-amd64.tar.gz"} {
+		assert.Nil(t, os.WriteFile(filepath.Join(downloadsDir, name), nil, 0644))
+	}
+
+	filename, ok := cachedPackage("1.21.4", "linux", "arm")
+	assert.True(t, ok)
+	assert.Equal(t, filepath.Join(downloadsDir, "go1.21.4.linux-armv6l.tar.gz"), filename)
+
+	filename, ok = cachedPackage("1.21.4", "linux", "arm64")
+	assert.True(t, ok)
+	assert.Equal(t, filepath.Join(downloadsDir, "go1.21.4.linux-arm64.tar.gz"), filename)
+
+	_, ok = cachedPackage("1.21.4", "linux", "amd64")
+	assert.False(t, ok)
+}
+
+func Test_packageFile(t *testing.T) {
+	saved := downloadsDir
+	defer func() { downloadsDir = saved }()
+	downloadsDir = t.TempDir()
+
+	t.Run("普通文件名", func(t *testing.T) {
+		filename, err := packageFile(&version.Package{FileName: "go1.21.4.linux-arm64.tar.gz"})
+		assert.Nil(t, err)
+		assert.Equal(t, filepath.Join(downloadsDir, "go1.21.4.linux-arm64.tar.gz"), filename)
+	})
+
+	t.Run("镜像站点提供的非法文件名", func(t *testing.T) {
+		for _, name := range []string{"", ".", "..", "../go1.21.4.linux-arm64.tar.gz", "a/go1.21.4.linux-arm64.tar.gz", `..\go1.21.4.windows-amd64.zip`, "/tmp/go1.21.4.linux-arm64.tar.gz"} {
+			_, err := packageFile(&version.Package{FileName: name})
+			assert.True(t, errors.Is(err, errs.ErrUnsafePackageName), name)
+		}
+	})
+}
+
+func Test_extractPackage(t *testing.T) {
+	filename := writeGoArchive(t, map[string]string{
+		"go/VERSION":   "go1.99.0",
+		"go/bin/go":    "#!/bin/sh",
+		"go/README.md": "readme",
+	})
+	dir := filepath.Join(t.TempDir(), "dist")
+	pkg := version.Package{FileName: "go1.99.0.linux-arm64.tar.gz"}
+
+	t.Run("解压至指定目录", func(t *testing.T) {
+		targetDir, err := extractPackage(filename, dir, &pkg)
+		assert.Nil(t, err)
+		assert.Equal(t, filepath.Join(dir, "go1.99.0.linux-arm64"), targetDir)
+		data, err := os.ReadFile(filepath.Join(targetDir, "VERSION"))
+		assert.Nil(t, err)
+		assert.Equal(t, "go1.99.0", string(data))
+
+		entries, err := os.ReadDir(dir)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(entries)) // no staging directory left behind
+	})
+
+	t.Run("目标目录已存在", func(t *testing.T) {
+		_, err := extractPackage(filename, dir, &pkg)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("镜像站点提供的非法文件名", func(t *testing.T) {
+		_, err := extractPackage(filename, dir, &version.Package{FileName: "../go1.99.0.linux-arm64.tar.gz"})
+		assert.True(t, errors.Is(err, errs.ErrUnsafePackageName))
+		_, err = os.Stat(filepath.Join(filepath.Dir(dir), "go1.99.0.linux-arm64"))
+		assert.True(t, os.IsNotExist(err))
+	})
+}
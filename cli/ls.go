@@ -45,7 +45,7 @@ func list(ctx *cli.Context) (err error) {
 		renderMode = textMode
 	}
 
-	render(renderMode, installed(), items, ansi.NewAnsiStdout())
+	render(renderMode, installed(), installedAliases(items), items, ansi.NewAnsiStdout())
 	return nil
 }
 
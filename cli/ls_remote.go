@@ -20,10 +20,10 @@
 package cli
 
 import (
+	"fmt"
 	"os"
 	"strings"
 
-	"github.com/Masterminds/semver/v3"
 	"github.com/k0kubun/go-ansi"
 	"github.com/urfave/cli/v2"
 	"github.com/voidint/g/collector"
@@ -39,9 +39,13 @@ const (
 func listRemote(ctx *cli.Context) (err error) {
 	vname := ctx.Args().First()
 
-	var cs *semver.Constraints
-	if vname != "" && vname != stableChannel && vname != unstableChannel && vname != archivedChannel && vname != version.Latest {
-		if cs, err = semver.NewConstraint(vname); err != nil {
+	var cs *version.Constraint
+	if vname != "" && vname != stableChannel && vname != unstableChannel && vname != archivedChannel && !namesOneVersion(vname) {
+		var opts []func(c *version.Constraint)
+		if ctx.Bool("prerelease") {
+			opts = append(opts, version.WithPrerelease())
+		}
+		if cs, err = version.NewConstraint(vname, opts...); err != nil {
 			return cli.Exit(errstring(err), 1)
 		}
 	}
@@ -51,6 +55,7 @@ func listRemote(ctx *cli.Context) (err error) {
 		return cli.Exit(errstring(err), 1)
 	}
 
+	ex := &version.Explanation{Query: vname, Mirror: c.Name() + "|" + c.URL(), Candidates: []version.Candidate{}}
 	var vs []*version.Version
 	switch vname {
 	case stableChannel:
@@ -60,28 +65,30 @@ func listRemote(ctx *cli.Context) (err error) {
 	case archivedChannel:
 		vs, err = c.ArchivedVersions()
 	default:
-		vs, err = c.AllVersions()
-		if err == nil {
-			if vname == version.Latest {
-				vs = []*version.Version{vs[len(vs)-1]}
-			}
-
-			if vname != "" && vname != version.Latest {
-				var newVs []*version.Version
-				for _, v := range vs {
-					if v.MatchConstraint(cs) {
-						newVs = append(newVs, v)
-					}
-				}
-				vs = newVs
+		if vs, err = c.AllVersions(); err == nil {
+			if vs, ex, err = selectRemote(vs, vname, cs, ctx.Bool("prerelease"), ex); err != nil && ctx.Bool("explain") {
+				explain(ctx, ex)
 			}
 		}
-
 	}
 	if err != nil {
 		return cli.Exit(errstring(err), 1)
 	}
 
+	if ctx.Bool("explain") {
+		if len(ex.Candidates) == 0 {
+			reason := "no version filter"
+			if vname != "" {
+				reason = fmt.Sprintf("in the %s channel", vname)
+			}
+			for _, v := range vs {
+				ex.Candidates = append(ex.Candidates, version.Candidate{Version: v.Name(), Result: version.CandidateSelected, Reason: reason})
+			}
+		}
+		explain(ctx, ex)
+		return nil
+	}
+
 	var renderMode uint8
 	switch ctx.String("output") {
 	case "json":
@@ -90,6 +97,42 @@ func listRemote(ctx *cli.Context) (err error) {
 		renderMode = textMode
 	}
 
-	render(renderMode, installed(), vs, ansi.NewAnsiStdout())
+	render(renderMode, installed(), nil, vs, ansi.NewAnsiStdout())
 	return nil
 }
+
+// namesOneVersion reports whether the version name names a single version, i.e. a symbolic selector or a minor line (e.g. '1.21' for the latest 1.21.x).
+func namesOneVersion(vname string) bool {
+	return version.IsSelector(vname) || version.IsMinorLine(vname)
+}
+
+// selectRemote narrows the remote versions down to those named by the version name, which is either a name of a single version or the constraint cs.
+func selectRemote(vs []*version.Version, vname string, cs *version.Constraint, prerelease bool, ex *version.Explanation) ([]*version.Version, *version.Explanation, error) {
+	if vname == "" {
+		return vs, ex, nil
+	}
+
+	if namesOneVersion(vname) {
+		opts, err := finderOptions(vname, prerelease)
+		if err != nil {
+			return nil, ex, err
+		}
+		v, fex, err := version.NewFinder(vs, append(opts, version.WithFinderInstalled())...).Explain(vname)
+		fex.Mirror = ex.Mirror
+		if err != nil {
+			return nil, fex, err
+		}
+		return []*version.Version{v}, fex, nil
+	}
+
+	var newVs []*version.Version
+	for _, v := range vs {
+		if cs.Check(v) {
+			newVs = append(newVs, v)
+			ex.Candidates = append(ex.Candidates, version.Candidate{Version: v.Name(), Result: version.CandidateSelected, Reason: fmt.Sprintf("matches constraint %q", vname)})
+		} else {
+			ex.Candidates = append(ex.Candidates, version.Candidate{Version: v.Name(), Result: version.CandidateRejected, Reason: fmt.Sprintf("does not match constraint %q", vname)})
+		}
+	}
+	return newVs, ex, nil
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/voidint/g/version"
+)
+
+func Test_selectRemote(t *testing.T) {
+	vs := []*version.Version{
+		version.MustNew("1.20.14"),
+		version.MustNew("1.21.0"),
+		version.MustNew("1.21.4"),
+		version.MustNew("1.22rc1"),
+		version.MustNew("1.22.3"),
+	}
+	cs, err := version.NewConstraint("1.21.x")
+	assert.Nil(t, err)
+
+	tests := []struct {
+		name     string
+		vname    string
+		cs       *version.Constraint
+		expected []string
+		wantErr  bool
+	}{
+		{name: "未指定版本", vname: "", expected: []string{"1.20.14", "1.21.0", "1.21.4", "1.22rc1", "1.22.3"}},
+		{name: "次版本表示该版本线的最新补丁版", vname: "1.21", expected: []string{"1.21.4"}},
+		{name: "次版本不包含预发布版本", vname: "1.22", expected: []string{"1.22.3"}},
+		{name: "符号选择器", vname: version.OldStable, expected: []string{"1.21.4"}},
+		{name: "版本约束", vname: "1.21.x", cs: cs, expected: []string{"1.21.0", "1.21.4"}},
+		{name: "次版本不存在", vname: "1.19", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := selectRemote(vs, tt.vname, tt.cs, false, &version.Explanation{Query: tt.vname})
+			if tt.wantErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			names := make([]string, 0, len(got))
+			for _, v := range got {
+				names = append(names, v.Name())
+			}
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+}
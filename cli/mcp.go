@@ -32,6 +32,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/urfave/cli/v2"
 	"github.com/voidint/g/build"
+	"github.com/voidint/g/version"
 )
 
 func runMcpServer(_ *cli.Context) (err error) {
@@ -132,7 +133,7 @@ func cleanHandler(ctx context.Context, req *protocol.CallToolRequest) (*protocol
 }
 
 type UseReq struct {
-	Version string `json:"version" description:"The specific go sdk version number(e.g. '1.21.4')." required:"true"`
+	Version string `json:"version" description:"Go sdk version number keywords, matched against the installed versions. Keywords match the following patterns: \n1. Specific version (e.g. '1.21.4'); \n2. Latest version identifier 'latest'; \n3. Wildcards (e.g. '1.21.x', '1.x', '1.18.*'); \n4. Caret ranges for minor version compatibility (e.g. '^1', '^1.18', '^1.18.10'); \n5. Tilde ranges for patch version updates (e.g. '~1.18'); \n6. Greater than comparisons (e.g. '>1.18'); \n7. Less than comparisons (e.g. '<1.16'); \n8. Version ranges (e.g. '1.18-1.20'); \n9. Newest stable version identifier 'stable'; \n10. Latest patch of the previous minor line identifier 'oldstable'; \n11. Newest release candidate or beta identifier 'next'; \n12. Latest patch of a minor line (e.g. '1.21'); \n13. Lowest version satisfying the go.mod file identifier 'min-go'; \n14. Names with the 'go' prefix (e.g. 'go1.21.4'); \n15. Exclusions (e.g. '>=1.21, !=1.21.2'); \n16. Alternatives (e.g. '1.20.x || 1.22.x'); \n17. Aliases defined with 'g alias' (e.g. 'work');" required:"true"`
 }
 
 func useHandler(ctx context.Context, req *protocol.CallToolRequest) (*protocol.CallToolResult, error) {
@@ -144,7 +145,7 @@ func useHandler(ctx context.Context, req *protocol.CallToolRequest) (*protocol.C
 	cmd := exec.CommandContext(ctx, "g", "use", useReq.Version)
 	output, err := cmd.Output()
 	if err != nil {
-		return nil, errors.WithStack(err)
+		return explainFailure(ctx, err, "use", "--explain", "--output", "json", useReq.Version)
 	}
 
 	return &protocol.CallToolResult{
@@ -190,8 +191,9 @@ func lsHandler(ctx context.Context, req *protocol.CallToolRequest) (*protocol.Ca
 }
 
 type LsRemoteReq struct {
-	Version string `json:"version" description:"Go sdk version number keywords. Optional values include: stable, unstable, archived, or a version pattern. \nSupported patterns: \n1. Specific version (e.g. '1.21.4'); \n2. Latest version identifier 'latest'; \n3. Wildcards (e.g. '1.21.x', '1.x', '1.18.*'); \n4. Caret ranges for minor version compatibility (e.g. '^1', '^1.18', '^1.18.10'); \n5. Tilde ranges for patch version updates (e.g. '~1.18'); \n6. Greater than comparisons (e.g. '>1.18'); \n7. Less than comparisons (e.g. '<1.16'); \n8. Version ranges (e.g. '1.18-1.20');" required:"false"`
-	Output  string `json:"output" description:"Output format. Optional values: json, text" required:"false"`
+	Version    string `json:"version" description:"Go sdk version number keywords. Optional values include: stable, unstable, archived, oldstable (latest patch of the previous minor line), next (newest release candidate or beta), min-go (lowest version satisfying the go.mod file), or a version pattern. \nSupported patterns: \n1. Specific version (e.g. '1.21.4'); \n2. Latest version identifier 'latest'; \n3. Wildcards (e.g. '1.21.x', '1.x', '1.18.*'); \n4. Caret ranges for minor version compatibility (e.g. '^1', '^1.18', '^1.18.10'); \n5. Tilde ranges for patch version updates (e.g. '~1.18'); \n6. Greater than comparisons (e.g. '>1.18'); \n7. Less than comparisons (e.g. '<1.16'); \n8. Version ranges (e.g. '1.18-1.20'); \n9. Names with the 'go' prefix (e.g. 'go1.21.4'); \n10. Exclusions (e.g. '>=1.21, !=1.21.2'); \n11. Alternatives (e.g. '1.20.x || 1.22.x');" required:"false"`
+	Output     string `json:"output" description:"Output format. Optional values: json, text" required:"false"`
+	Prerelease bool   `json:"prerelease" description:"Let version patterns match release candidates and betas too." required:"false"`
 }
 
 func lsRemoteHandler(ctx context.Context, req *protocol.CallToolRequest) (*protocol.CallToolResult, error) {
@@ -206,7 +208,7 @@ func lsRemoteHandler(ctx context.Context, req *protocol.CallToolRequest) (*proto
 		lsrReq.Output = "json"
 	}
 
-	cmd := exec.CommandContext(ctx, "g", "ls-remote", "-o", lsrReq.Output, lsrReq.Version)
+	cmd := exec.CommandContext(ctx, "g", "ls-remote", "-o", lsrReq.Output, fmt.Sprintf("--prerelease=%t", lsrReq.Prerelease), lsrReq.Version)
 	output, err := cmd.Output()
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -236,9 +238,10 @@ func lsRemoteHandler(ctx context.Context, req *protocol.CallToolRequest) (*proto
 }
 
 type InstallReq struct {
-	Version      string `json:"version" description:"Go sdk version number keywords. Keywords match the following patterns: \n1. Specific version (e.g. '1.21.4'); \n2. Latest version identifier 'latest'; \n3. Wildcards (e.g. '1.21.x', '1.x', '1.18.*'); \n4. Caret ranges for minor version compatibility (e.g. '^1', '^1.18', '^1.18.10'); \n5. Tilde ranges for patch version updates (e.g. '~1.18'); \n6. Greater than comparisons (e.g. '>1.18'); \n7. Less than comparisons (e.g. '<1.16'); \n8. Version ranges (e.g. '1.18-1.20');" required:"true"`
+	Version      string `json:"version" description:"Go sdk version number keywords. Keywords match the following patterns: \n1. Specific version (e.g. '1.21.4'); \n2. Latest version identifier 'latest'; \n3. Wildcards (e.g. '1.21.x', '1.x', '1.18.*'); \n4. Caret ranges for minor version compatibility (e.g. '^1', '^1.18', '^1.18.10'); \n5. Tilde ranges for patch version updates (e.g. '~1.18'); \n6. Greater than comparisons (e.g. '>1.18'); \n7. Less than comparisons (e.g. '<1.16'); \n8. Version ranges (e.g. '1.18-1.20'); \n9. Newest stable version identifier 'stable'; \n10. Latest patch of the previous minor line identifier 'oldstable'; \n11. Newest release candidate or beta identifier 'next'; \n12. Latest patch of a minor line (e.g. '1.21'); \n13. Lowest version satisfying the go.mod file identifier 'min-go'; \n14. Names with the 'go' prefix (e.g. 'go1.21.4'); \n15. Exclusions (e.g. '>=1.21, !=1.21.2'); \n16. Alternatives (e.g. '1.20.x || 1.22.x');" required:"true"`
 	Nouse        bool   `json:"nouse" description:"Don't use the version after installed." required:"false"`
 	SkipChecksum bool   `json:"skip-checksum" description:"Skip checksum verification." required:"false"`
+	Prerelease   bool   `json:"prerelease" description:"Let version patterns match release candidates and betas too." required:"false"`
 }
 
 func installHandler(ctx context.Context, req *protocol.CallToolRequest) (*protocol.CallToolResult, error) {
@@ -247,10 +250,10 @@ func installHandler(ctx context.Context, req *protocol.CallToolRequest) (*protoc
 		return nil, err
 	}
 
-	cmd := exec.CommandContext(ctx, "g", "install", fmt.Sprintf("--nouse=%t", installReq.Nouse), fmt.Sprintf("--skip-checksum=%t", installReq.SkipChecksum), installReq.Version)
+	cmd := exec.CommandContext(ctx, "g", "install", fmt.Sprintf("--nouse=%t", installReq.Nouse), fmt.Sprintf("--skip-checksum=%t", installReq.SkipChecksum), fmt.Sprintf("--prerelease=%t", installReq.Prerelease), installReq.Version)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return nil, errors.WithStack(err)
+		return explainFailure(ctx, err, "install", "--explain", "--output", "json", fmt.Sprintf("--prerelease=%t", installReq.Prerelease), installReq.Version)
 	}
 
 	if !strings.Contains(string(output), "installed") {
@@ -294,3 +297,23 @@ func uninstallHandler(ctx context.Context, req *protocol.CallToolRequest) (*prot
 		},
 	}, nil
 }
+
+// explainFailure returns the explanation of the failed version lookup, with the suggested versions and platforms, as the error result
+// of the tool. It returns the error itself if the command failed for other reasons.
+func explainFailure(ctx context.Context, err error, args ...string) (*protocol.CallToolResult, error) {
+	output, _ := exec.CommandContext(ctx, "g", args...).Output()
+	var ex version.Explanation
+	if e := json.Unmarshal(output, &ex); e != nil || ex.Error == "" {
+		return nil, errors.WithStack(err)
+	}
+
+	return &protocol.CallToolResult{
+		Content: []protocol.Content{
+			&protocol.TextContent{
+				Type: "text",
+				Text: string(output),
+			},
+		},
+		IsError: true,
+	}, nil
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+	"github.com/voidint/g/collector"
+	"github.com/voidint/g/pkg/policy"
+	"github.com/voidint/g/version"
+)
+
+// policyFile is the global policy file in the g home.
+const policyFile = "policy.json"
+
+// loadPolicy loads the global policy file and the nearest per-project policy file.
+func loadPolicy() (*policy.Policy, error) {
+	files := []string{filepath.Join(ghomeDir, policyFile)}
+	if wd, err := os.Getwd(); err == nil {
+		if filename, ok := policy.FindProjectFile(wd); ok {
+			files = append(files, filename)
+		}
+	}
+	return policy.Load(files...)
+}
+
+// mirrors returns the download pages in G_MIRROR, or the official download page if it is not set.
+func mirrors() []string {
+	var items []string
+	for _, item := range strings.Split(os.Getenv(mirrorEnv), mirrorSep) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	if len(items) == 0 {
+		items = append(items, collector.OfficialDownloadPageURL)
+	}
+	return items
+}
+
+// checkVersionPolicy checks the version against the policy, querying the latest stable version if a rule needs it.
+// The collector is created on demand when c is nil.
+func checkVersionPolicy(p *policy.Policy, vname string, c collector.Collector) (err error) {
+	var latest string
+	if p.NeedsLatest() {
+		if c == nil {
+			if c, err = collector.NewCollector(strings.Split(os.Getenv(mirrorEnv), mirrorSep)...); err != nil {
+				return err
+			}
+		}
+		if latest, err = latestStable(c); err != nil {
+			return err
+		}
+	}
+	return p.CheckVersion(vname, latest)
+}
+
+// latestStable returns the latest stable version.
+func latestStable(c collector.Collector) (string, error) {
+	items, err := c.StableVersions()
+	if err != nil {
+		return "", err
+	}
+	if len(items) == 0 {
+		return "", nil
+	}
+	sort.Sort(version.Collection(items))
+	return items[len(items)-1].Name(), nil
+}
+
+func checkPolicy(ctx *cli.Context) (err error) {
+	p, err := loadPolicy()
+	if err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+	if p.Empty() {
+		fmt.Println("No policy file found")
+		return nil
+	}
+	for _, filename := range p.Files() {
+		fmt.Println("Loaded", filename)
+	}
+
+	if err = p.CheckMirrors(mirrors()); err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+
+	vname := ctx.Args().First()
+	if vname == "" {
+		if vname = inuse(goroot); vname == "." || vname == "" {
+			fmt.Println("No version in use, only the mirrors were checked")
+			return nil
+		}
+	}
+	if err = checkVersionPolicy(p, vname, nil); err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+	fmt.Printf("go%s complies with the policy\n", vname)
+	return nil
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/urfave/cli/v2"
+)
+
+// sessionShells are the shells supported by 'g shell'.
+var sessionShells = map[string]bool{
+	bashShell: true,
+	zshShell:  true,
+	fishShell: true,
+	pwshShell: true,
+	cmdShell:  true,
+}
+
+// detectShell guesses the shell of the session if it is not specified.
+func detectShell() string {
+	if runtime.GOOS == "windows" {
+		if os.Getenv("PSModulePath") != "" {
+			return pwshShell
+		}
+		return cmdShell
+	}
+	if sh := filepath.Base(os.Getenv("SHELL")); sessionShells[sh] {
+		return sh
+	}
+	return bashShell
+}
+
+// shell prints the statements switching the session to the version, which are evaluated by the 'g' function of the shell init script.
+func shell(ctx *cli.Context) (err error) {
+	sh := ctx.String("shell")
+	if sh == "" {
+		sh = detectShell()
+	}
+	if !sessionShells[sh] {
+		return cli.Exit(wrapstring(fmt.Sprintf("Unsupported shell %q, one of: [bash|zsh|fish|pwsh|cmd]", sh)), 1)
+	}
+
+	var stmts []string
+	if ctx.Bool("unset") {
+		stmts = append(hookStatements(sh, "", os.Getenv), unsetEnv(sh, goVersionEnv))
+	} else {
+		vname := ctx.Args().First()
+		if vname == "" {
+			return cli.ShowSubcommandHelp(ctx)
+		}
+		if vname, err = resolveAlias(vname); err != nil {
+			return cli.Exit(errstring(err), 1)
+		}
+		v, err := findInstalled(vname, ctx.Bool("install"))
+		if err != nil {
+			return cli.Exit(errstring(err), 1)
+		}
+
+		p, err := loadPolicy()
+		if err != nil {
+			return cli.Exit(errstring(err), 1)
+		}
+		if err = checkVersionPolicy(p, v.Name(), nil); err != nil {
+			return cli.Exit(errstring(err), 1)
+		}
+
+		stmts = append(hookStatements(sh, filepath.Join(versionsDir, v.Name()), os.Getenv), exportEnv(sh, goVersionEnv, v.Name()))
+	}
+
+	for _, stmt := range stmts {
+		fmt.Println(stmt)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_detectShell(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the shell is detected by PSModulePath on windows")
+	}
+	t.Setenv("SHELL", "/usr/bin/fish")
+	assert.Equal(t, fishShell, detectShell())
+	t.Setenv("SHELL", "/bin/tcsh")
+	assert.Equal(t, bashShell, detectShell())
+}
+
+func Test_cmdStatements(t *testing.T) {
+	assert.Equal(t, `set "GOROOT=C:\Users\voidint\.g\versions\1.21.4"`, exportEnv(cmdShell, "GOROOT", `C:\Users\voidint\.g\versions\1.21.4`))
+	assert.Equal(t, "set G_GO_VERSION=", unsetEnv(cmdShell, "G_GO_VERSION"))
+}
+
+func Test_hookTarget_pinned(t *testing.T) {
+	rootDir := t.TempDir()
+	goroot = filepath.Join(rootDir, "go")
+	versionsDir = filepath.Join(rootDir, "versions")
+	_ = os.MkdirAll(filepath.Join(versionsDir, "1.20.14"), 0755)
+	_ = os.MkdirAll(filepath.Join(versionsDir, "1.21.4"), 0755)
+	projectDir := t.TempDir()
+	assert.Nil(t, os.WriteFile(filepath.Join(projectDir, ".go-version"), []byte("1.21.4\n"), 0600))
+
+	// The version pinned by 'g shell' wins over the project files.
+	t.Setenv("G_GO_VERSION", "1.20.14")
+	target, err := hookTarget(projectDir, false)
+	assert.Nil(t, err)
+	assert.Equal(t, filepath.Join(versionsDir, "1.20.14"), target)
+}
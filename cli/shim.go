@@ -0,0 +1,174 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+)
+
+// shimMarker identifies the launchers written by g, so that other files are never removed.
+const shimMarker = "g shim"
+
+// shimTools are the programs of the Go distribution that get a launcher.
+var shimTools = []string{"go", "gofmt"}
+
+// shimDir returns the directory of the launchers, which precedes GOROOT/bin in PATH.
+func shimDir() string {
+	return filepath.Join(ghomeDir, "bin")
+}
+
+// shimFile returns the file name and content of the launcher of the tool, which calls g back to resolve the version per invocation.
+func shimFile(tool, self string) (filename string, content []byte) {
+	if runtime.GOOS == "windows" {
+		return filepath.Join(shimDir(), tool+".cmd"),
+			[]byte(fmt.Sprintf("@echo off\r\nrem %s, generated by 'g shim enable'\r\n\"%s\" shim-exec %s %%*\r\n", shimMarker, self, tool))
+	}
+	return filepath.Join(shimDir(), tool),
+		[]byte(fmt.Sprintf("#!/bin/sh\n# %s, generated by 'g shim enable'\nexec %s shim-exec %s \"$@\"\n", shimMarker, quote(bashShell, self), tool))
+}
+
+// isShim reports whether the file is a launcher written by g.
+func isShim(filename string) bool {
+	data, err := os.ReadFile(filename)
+	return err == nil && bytes.Contains(data, []byte(shimMarker))
+}
+
+func enableShims(ctx *cli.Context) (err error) {
+	self, err := os.Executable()
+	if err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+	if err = os.MkdirAll(shimDir(), 0755); err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+	for _, tool := range shimTools {
+		filename, content := shimFile(tool, self)
+		if _, err = os.Stat(filename); err == nil && !isShim(filename) {
+			return cli.Exit(wrapstring(fmt.Sprintf("%q exists and is not a launcher of g, please remove it first.", filename)), 1)
+		}
+		if err = os.WriteFile(filename, content, 0755); err != nil { // the launchers are executables
+			return cli.Exit(errstring(err), 1)
+		}
+		fmt.Printf("Wrote %q\n", filename)
+	}
+	fmt.Printf("Make sure %q precedes the bin directory of GOROOT in PATH.\n", shimDir())
+	return nil
+}
+
+func disableShims(ctx *cli.Context) (err error) {
+	self, _ := os.Executable()
+	for _, tool := range shimTools {
+		filename, _ := shimFile(tool, self)
+		if !isShim(filename) {
+			continue
+		}
+		if err = os.Remove(filename); err != nil {
+			return cli.Exit(errstring(err), 1)
+		}
+		fmt.Printf("Removed %q\n", filename)
+	}
+	return nil
+}
+
+// shimExec runs the tool of the version selected for the working directory. It is called by the launchers.
+func shimExec(ctx *cli.Context) (err error) {
+	args := ctx.Args().Slice()
+	if len(args) == 0 {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+	tool := args[0]
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+	r, err := resolveVersion(wd)
+	if err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+	if r == nil {
+		return cli.Exit(wrapstring("No version in use, please switch to a version with 'g use' first."), 1)
+	}
+	if !r.Installed {
+		return cli.Exit(wrapstring(fmt.Sprintf("The <%s> version selected by %s is not installed, please install it first with 'g install'.", r.Version, r.Source)), 1)
+	}
+
+	p, err := loadPolicy()
+	if err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+	// The shims run on every call of the tools, so the rules needing the network are left to 'g use' and 'g install'.
+	if err = p.CheckLocalVersion(r.Version); err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+
+	root := filepath.Join(versionsDir, r.Version)
+	path := filepath.Join(root, "bin", tool)
+	if runtime.GOOS == "windows" {
+		path += ".exe"
+	}
+	if err = execve(path, append([]string{path}, args[1:]...), toolEnv(os.Environ(), root)); err != nil {
+		return cli.Exit(errstring(errors.Wrapf(err, "failed to run %q", path)), 1)
+	}
+	return nil
+}
+
+// toolEnv returns the environment running the tools of the Go root, whose bin directory is put in front of PATH.
+func toolEnv(environ []string, root string) []string {
+	return setEnv(setEnv(environ, "GOROOT", root), "PATH", prependPathEntry(getEnv(environ, "PATH"), root))
+}
+
+// getEnv returns the value of the variable in the environment.
+func getEnv(environ []string, key string) string {
+	for i := len(environ) - 1; i >= 0; i-- {
+		if k, v, ok := strings.Cut(environ[i], "="); ok && envKeyEqual(k, key) {
+			return v
+		}
+	}
+	return ""
+}
+
+// setEnv sets the variable in the environment, replacing the existing one.
+func setEnv(environ []string, key, value string) []string {
+	env := make([]string, 0, len(environ)+1)
+	for _, kv := range environ {
+		if k, _, ok := strings.Cut(kv, "="); ok && envKeyEqual(k, key) {
+			continue
+		}
+		env = append(env, kv)
+	}
+	return append(env, key+"="+value)
+}
+
+// envKeyEqual compares the names of environment variables, which are case-insensitive on Windows.
+func envKeyEqual(a, b string) bool {
+	if runtime.GOOS == "windows" {
+		return strings.EqualFold(a, b)
+	}
+	return a == b
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_enableShims(t *testing.T) {
+	ghomeDir = t.TempDir()
+
+	assert.Nil(t, enableShims(nil))
+	for _, tool := range shimTools {
+		filename, content := shimFile(tool, "/opt/g")
+		assert.True(t, isShim(filename))
+		if runtime.GOOS != "windows" {
+			assert.Equal(t, filepath.Join(shimDir(), tool), filename)
+			assert.Equal(t, "#!/bin/sh\n# g shim, generated by 'g shim enable'\nexec '/opt/g' shim-exec "+tool+" \"$@\"\n", string(content))
+		}
+	}
+
+	t.Run("停用后删除启动器", func(t *testing.T) {
+		assert.Nil(t, disableShims(nil))
+		entries, err := os.ReadDir(shimDir())
+		assert.Nil(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("不覆盖非g生成的文件", func(t *testing.T) {
+		filename, _ := shimFile("go", "/opt/g")
+		assert.Nil(t, os.WriteFile(filename, []byte("#!/bin/sh\n"), 0755))
+		assert.NotNil(t, enableShims(nil))
+
+		assert.Nil(t, disableShims(nil))
+		_, err := os.Stat(filename)
+		assert.Nil(t, err)
+	})
+}
+
+func Test_toolEnv(t *testing.T) {
+	root := filepath.Join("/home", ".g", "versions", "1.21.4")
+	env := toolEnv([]string{"HOME=/home", "GOROOT=/home/.g/go", "PATH=/usr/bin"}, root)
+	assert.Equal(t, []string{
+		"HOME=/home",
+		"GOROOT=" + root,
+		"PATH=" + filepath.Join(root, "bin") + string(os.PathListSeparator) + "/usr/bin",
+	}, env)
+	assert.Equal(t, root, getEnv(env, "GOROOT"))
+	assert.Equal(t, "", getEnv(env, "GOPATH"))
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package cli
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/voidint/g/pkg/archive"
+	"github.com/voidint/g/pkg/errs"
+	"github.com/voidint/g/pkg/gosumdb"
+	httppkg "github.com/voidint/g/pkg/http"
+)
+
+// installToolchainModule downloads the golang.org/toolchain module of the version from the module proxy,
+// authenticates it against the checksum database and installs it.
+func installToolchainModule(vname string) (err error) {
+	modPath, modVersion := gosumdb.ToolchainModulePath, gosumdb.ToolchainVersion(vname, runtime.GOOS, runtime.GOARCH)
+
+	client, err := gosumdb.NewClient(os.Getenv(gosumdbEnv), filepath.Join(ghomeDir, "sumdb"))
+	if err != nil {
+		return err
+	}
+
+	filename := filepath.Join(downloadsDir, fmt.Sprintf("toolchain@%s.zip", modVersion))
+	if _, err = os.Stat(filename); os.IsNotExist(err) {
+		proxy, err := gosumdb.Proxy(os.Getenv(goproxyEnv))
+		if err != nil {
+			return err
+		}
+		url, err := gosumdb.ZipURL(proxy, modPath, modVersion)
+		if err != nil {
+			return err
+		}
+		if _, err = httppkg.Download(url, filename, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644, true); err != nil {
+			_ = os.Remove(filename)
+			return err
+		}
+	}
+
+	fmt.Printf("Verifying %s@%s against %s\n", modPath, modVersion, client.Name())
+	if err = client.VerifyZip(modPath, modVersion, filename); err != nil {
+		_ = os.Remove(filename)
+		return err
+	}
+	fmt.Println("Checksum database verified")
+
+	return installWith(filename, vname, extractToolchainZip)
+}
+
+// extractToolchainZip extracts the golang.org/toolchain module zip and returns the root directory of the Go distribution.
+func extractToolchainZip(filename, dstDir string) (rootDir string, err error) {
+	// The files are rooted at 'golang.org/toolchain@<version>/'.
+	topDir, err := archive.Extract(filename, dstDir)
+	if err != nil {
+		return "", err
+	}
+	entries, err := os.ReadDir(topDir)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	if len(entries) != 1 || !entries[0].IsDir() || !strings.HasPrefix(entries[0].Name(), "toolchain@") {
+		return "", errs.NewMalformedArchiveError(filepath.Base(topDir), "not a golang.org/toolchain module zip")
+	}
+	rootDir = filepath.Join(topDir, entries[0].Name())
+
+	// Module zips do not carry file modes, so mark the executables the same way the go command does.
+	for _, dir := range []string{"bin", filepath.Join("pkg", "tool")} {
+		err = filepath.WalkDir(filepath.Join(rootDir, dir), func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			if !d.Type().IsRegular() {
+				return nil
+			}
+			return os.Chmod(path, 0755)
+		})
+		if err != nil {
+			return "", errors.WithStack(err)
+		}
+	}
+	return rootDir, nil
+}
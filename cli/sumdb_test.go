@@ -0,0 +1,121 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package cli
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/voidint/g/pkg/gosumdb"
+	"golang.org/x/mod/sumdb"
+	"golang.org/x/mod/sumdb/dirhash"
+	"golang.org/x/mod/sumdb/note"
+)
+
+func Test_installToolchainModule(t *testing.T) {
+	ghomeDir = t.TempDir()
+	versionsDir = filepath.Join(ghomeDir, "versions")
+	downloadsDir = filepath.Join(ghomeDir, "downloads")
+	_ = os.MkdirAll(versionsDir, 0755)
+	_ = os.MkdirAll(downloadsDir, 0755)
+
+	modVersion := gosumdb.ToolchainVersion("1.99.0", runtime.GOOS, runtime.GOARCH)
+	prefix := gosumdb.ToolchainModulePath + "@" + modVersion + "/"
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, body := range map[string]string{
+		"VERSION":                  "go1.99.0",
+		"bin/go":                   "#!/bin/sh",
+		"pkg/tool/linux_amd64/vet": "#!/bin/sh",
+	} {
+		w, err := zw.Create(prefix + name)
+		assert.Nil(t, err)
+		_, _ = w.Write([]byte(body))
+	}
+	assert.Nil(t, zw.Close())
+	zipData := buf.Bytes()
+
+	zipFile := filepath.Join(t.TempDir(), "toolchain.zip")
+	assert.Nil(t, os.WriteFile(zipFile, zipData, 0644))
+	hash, err := dirhash.HashZip(zipFile, dirhash.Hash1)
+	assert.Nil(t, err)
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/golang.org/toolchain/@v/"+modVersion+".zip" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(zipData)
+	}))
+	defer proxy.Close()
+
+	skey, vkey, err := note.GenerateKey(rand.Reader, "sum.example.com")
+	assert.Nil(t, err)
+	sumdbSrv := httptest.NewServer(sumdb.NewServer(sumdb.NewTestServer(skey, func(path, vers string) ([]byte, error) {
+		if vers != modVersion {
+			return nil, fmt.Errorf("%s@%s: not found", path, vers)
+		}
+		return []byte(fmt.Sprintf("%s %s %s\n", path, vers, hash)), nil
+	})))
+	defer sumdbSrv.Close()
+
+	t.Setenv(goproxyEnv, proxy.URL+",direct")
+	t.Setenv(gosumdbEnv, vkey+" "+sumdbSrv.URL)
+
+	t.Run("安装经校验和数据库验证的工具链", func(t *testing.T) {
+		assert.Nil(t, installToolchainModule("1.99.0"))
+
+		data, err := os.ReadFile(filepath.Join(versionsDir, "1.99.0", "VERSION"))
+		assert.Nil(t, err)
+		assert.Equal(t, "go1.99.0", string(data))
+
+		if runtime.GOOS != "windows" {
+			fi, err := os.Stat(filepath.Join(versionsDir, "1.99.0", "pkg", "tool", "linux_amd64", "vet"))
+			assert.Nil(t, err)
+			assert.Equal(t, os.FileMode(0755), fi.Mode().Perm())
+		}
+
+		r, err := verifyVersion("1.99.0")
+		assert.Nil(t, err)
+		assert.True(t, r.OK())
+	})
+
+	t.Run("缓存的模块zip被篡改", func(t *testing.T) {
+		_ = os.RemoveAll(filepath.Join(versionsDir, "1.99.0"))
+		cached := filepath.Join(downloadsDir, fmt.Sprintf("toolchain@%s.zip", modVersion))
+		assert.Nil(t, os.WriteFile(cached, []byte("PK"), 0644))
+
+		assert.NotNil(t, installToolchainModule("1.99.0"))
+		_, err := os.Stat(cached)
+		assert.True(t, os.IsNotExist(err))
+		_, err = os.Stat(filepath.Join(versionsDir, "1.99.0"))
+		assert.True(t, os.IsNotExist(err))
+	})
+}
@@ -23,8 +23,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/urfave/cli/v2"
+	"github.com/voidint/g/version"
 )
 
 func uninstall(ctx *cli.Context) error {
@@ -32,15 +34,31 @@ func uninstall(ctx *cli.Context) error {
 	if vname == "" {
 		return cli.ShowSubcommandHelp(ctx)
 	}
+	target, err := resolveAlias(vname)
+	if err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+	if target != vname {
+		// The alias may refer to a version constraint.
+		versions, _ := listLocalVersions(versionsDir)
+		if vname, _ = matchLocalVersion(versions, target); vname == "" {
+			return cli.Exit(fmt.Sprintf("[g] %q version is not installed.%s", target, didYouMean(version.Suggest(target, versions))), 1)
+		}
+		if !version.IsValid(target) || version.IsMinorLine(strings.TrimPrefix(target, "go")) {
+			// Removing whatever the constraint happens to select is rarely what the user means.
+			return cli.Exit(fmt.Sprintf("[g] Alias %q refers to the constraint %q rather than a version. It currently selects %q, run 'g uninstall %s' to remove that version.", ctx.Args().First(), target, vname, vname), 1)
+		}
+	}
 	targetV := filepath.Join(versionsDir, vname)
 
 	if finfo, err := os.Stat(targetV); err != nil || !finfo.IsDir() {
-		return cli.Exit(fmt.Sprintf("[g] %q version is not installed", vname), 1)
+		return cli.Exit(fmt.Sprintf("[g] %q version is not installed.%s", vname, didYouMean(suggestInstalled(vname))), 1)
 	}
 
-	if err := os.RemoveAll(targetV); err != nil {
+	if err = os.RemoveAll(targetV); err != nil {
 		return cli.Exit(wrapstring(fmt.Sprintf("Uninstall failed: %s", err.Error())), 1)
 	}
+	_ = os.Remove(manifestFile(vname))
 	fmt.Printf("Uninstalled go%s\n", vname)
 	return nil
 }
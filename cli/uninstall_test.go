@@ -0,0 +1,67 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package cli
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli/v2"
+	"github.com/voidint/g/pkg/alias"
+)
+
+func Test_uninstall(t *testing.T) {
+	savedHome, savedVersions := ghomeDir, versionsDir
+	defer func() { ghomeDir, versionsDir = savedHome, savedVersions }()
+
+	ghomeDir = t.TempDir()
+	versionsDir = filepath.Join(ghomeDir, "versions")
+	for _, vname := range []string{"1.21.4", "1.22.3"} {
+		assert.Nil(t, os.MkdirAll(filepath.Join(versionsDir, vname), 0755))
+	}
+	assert.Nil(t, alias.Aliases{
+		"legacy": "1.21.x",
+		"line":   "1.21",
+		"work":   "1.22.3",
+	}.Save(filepath.Join(ghomeDir, aliasesFile)))
+
+	newContext := func(args ...string) *cli.Context {
+		set := flag.NewFlagSet("uninstall", flag.ContinueOnError)
+		assert.Nil(t, set.Parse(args))
+		return cli.NewContext(nil, set, nil)
+	}
+
+	t.Run("拒绝卸载指向版本约束的别名", func(t *testing.T) {
+		for _, name := range []string{"legacy", "line"} {
+			err := uninstall(newContext(name))
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), "g uninstall 1.21.4")
+			assert.DirExists(t, filepath.Join(versionsDir, "1.21.4"))
+		}
+	})
+
+	t.Run("卸载指向具体版本的别名", func(t *testing.T) {
+		assert.Nil(t, uninstall(newContext("work")))
+		assert.NoDirExists(t, filepath.Join(versionsDir, "1.22.3"))
+	})
+}
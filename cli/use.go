@@ -22,81 +22,251 @@ package cli
 import (
 	"fmt"
 	"os"
-	"path/filepath"
-	"regexp"
+	"strings"
 
-	"github.com/Masterminds/semver/v3"
 	"github.com/pkg/errors"
 	"github.com/urfave/cli/v2"
+	"github.com/voidint/g/pkg/project"
+	"github.com/voidint/g/version"
 )
 
 func use(ctx *cli.Context) error {
+	var target string
 	vname := ctx.Args().First()
-	if vname == "" {
-		// Uses go.mod if available and version is omitted
-		goModData, err := os.ReadFile("go.mod")
+	if vname != "" {
+		var err error
+		if vname, err = resolveAlias(vname); err != nil {
+			return cli.Exit(errstring(err), 1)
+		}
+		if ctx.Bool("explain") {
+			return explainLocalVersion(ctx, vname)
+		}
+		if target, err = matchInstalled(vname, ctx.Bool("install"), func(versions []*version.Version) (string, error) {
+			return matchLocalVersion(versions, vname)
+		}); err != nil {
+			return cli.Exit(errstring(err), 1)
+		}
+		if target == "" {
+			return cli.Exit(wrapstring(fmt.Sprintf("The %q version does not exist, please install it first or use --install.%s", vname, didYouMean(suggestInstalled(vname)))), 1)
+		}
+	} else if ctx.Bool("local") {
+		return cli.ShowSubcommandHelp(ctx)
+	} else {
+		// Uses the project files if available and version is omitted
+		sel, err := selectProjectVersion()
 		if err != nil {
-			if errors.Is(err, os.ErrNotExist) {
-				return cli.Exit(wrapstring("No go.mod file found"), 1)
+			return cli.Exit(errstring(err), 1)
+		}
+		if ctx.Bool("explain") {
+			if sel.Directive == "" {
+				return explainLocalVersion(ctx, sel.Version)
+			}
+			versions, err := listLocalVersions(versionsDir)
+			if err != nil {
+				return cli.Exit(errstring(err), 1)
+			}
+			ex := explainSelection(versions, sel, inuse(goroot))
+			explain(ctx, ex)
+			if ex.Error != "" {
+				return cli.Exit("", 1)
 			}
+			return nil
+		}
+		fmt.Printf("Found %s <%s>\n", describeSelection(sel), sel.Version)
+
+		if target, err = matchInstalled(sel.Version, ctx.Bool("install"), func(versions []*version.Version) (string, error) {
+			return matchSelection(versions, sel, inuse(goroot)), nil
+		}); err != nil {
 			return cli.Exit(errstring(err), 1)
 		}
+		if target == "" {
+			return cli.Exit(wrapstring(fmt.Sprintf("No installed version satisfies <%s>, please install it first with 'g install' or use --install.", sel.Version)), 1)
+		}
+	}
+
+	p, err := loadPolicy()
+	if err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+	if err = checkVersionPolicy(p, target, nil); err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
 
-		goDirective := getGoDirective(goModData)
+	if ctx.Bool("local") {
 		wd, err := os.Getwd()
 		if err != nil {
-			wd = "."
+			return cli.Exit(errstring(err), 1)
 		}
-		if goDirective == "" {
-			return cli.Exit(wrapstring(fmt.Sprintf("Go directive does not exist in %q files", filepath.Join(wd, "go.mod"))), 1)
+		// The concrete version is recorded, since the selectors, constraints and aliases are unknown to the other tools
+		// reading the version files and would change their meaning over time.
+		filename, err := project.WriteVersionFile(wd, target)
+		if err != nil {
+			return cli.Exit(errstring(err), 1)
 		}
-		fmt.Printf("Found %q with version <%s>\n", filepath.Join(wd, "go.mod"), goDirective)
-		vname = goDirective
+		fmt.Printf("Wrote <%s> to %q\n", target, filename)
 	}
 
-	versions, err := listLocalVersions(versionsDir)
-	if err != nil {
+	if err = switchVersion(target); err != nil {
 		return cli.Exit(errstring(err), 1)
 	}
+	return nil
+}
 
-	// Try to match the version number strictly first
-	for i := range versions {
-		if versions[i].Name() != vname {
-			continue
+// matchLocalVersion returns the installed version matching the version name, the symbolic selectors and
+// the minor lines (e.g. '1.21') included, preferring the latest one. It returns an empty string if none matches.
+func matchLocalVersion(versions []*version.Version, vname string) (string, error) {
+	opts, err := finderOptions(vname, false)
+	if err != nil {
+		return "", err
+	}
+	v, err := version.NewFinder(versions, append(opts, version.WithFinderInstalled())...).Find(vname)
+	if err == nil {
+		return v.Name(), nil
+	}
+	if !version.IsSelector(vname) {
+		if _, err = version.NewConstraint(vname); err != nil {
+			return "", err
 		}
-		if err = switchVersion(versions[i].Name()); err != nil {
-			return cli.Exit(errstring(err), 1)
+	}
+	return "", nil
+}
+
+// finderOptions returns the options of Finder for the version name. Release candidates and betas are matched if prerelease is true,
+// and 'min-go' resolves against the go.work or go.mod file of the working directory.
+func finderOptions(vname string, prerelease bool) ([]func(fdr *version.Finder), error) {
+	var opts []func(fdr *version.Finder)
+	if prerelease {
+		opts = append(opts, version.WithFinderPrerelease())
+	}
+	if vname == version.MinGo {
+		wd, err := os.Getwd()
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		minVersion, err := project.FindMinVersion(wd)
+		if err != nil {
+			return nil, err
 		}
+		opts = append(opts, version.WithFinderMinGo(minVersion))
+	}
+	return opts, nil
+}
+
+// matchInstalled returns the installed version chosen by match. If none is chosen and install is true,
+// the version name is installed non-interactively before matching again. It returns an empty string if none is chosen.
+func matchInstalled(vname string, install bool, match func(versions []*version.Version) (string, error)) (string, error) {
+	for i := 0; ; i++ {
+		versions, err := listLocalVersions(versionsDir)
+		if err != nil {
+			return "", err
+		}
+		target, err := match(versions)
+		if err != nil || target != "" || !install || i > 0 {
+			return target, err
+		}
+		if err = installVersion(vname); err != nil {
+			return "", err
+		}
+	}
+}
+
+// suggestInstalled returns the installed versions close to the version name not installed.
+func suggestInstalled(vname string) []string {
+	versions, err := listLocalVersions(versionsDir)
+	if err != nil {
 		return nil
 	}
+	return version.Suggest(vname, versions)
+}
 
-	// Try fuzzy matching the version number again
-	cs, err := semver.NewConstraint(vname)
+// didYouMean returns the sentence suggesting the versions, or an empty string if there is none.
+func didYouMean(suggestions []string) string {
+	if len(suggestions) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" Did you mean %s?", strings.Join(suggestions, ", "))
+}
+
+// explainLocalVersion prints how the version name is resolved against the installed versions.
+func explainLocalVersion(ctx *cli.Context, vname string) error {
+	versions, err := listLocalVersions(versionsDir)
+	if err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+	opts, err := finderOptions(vname, false)
 	if err != nil {
 		return cli.Exit(errstring(err), 1)
 	}
+	_, ex, err := version.NewFinder(versions, append(opts, version.WithFinderInstalled())...).Explain(vname)
+	explain(ctx, ex)
+	if err != nil {
+		return cli.Exit("", 1)
+	}
+	return nil
+}
 
-	for j := len(versions) - 1; j >= 0; j-- {
-		if !versions[j].MatchConstraint(cs) {
-			continue
-		}
-		if err = switchVersion(versions[j].Name()); err != nil {
-			return cli.Exit(errstring(err), 1)
+// explainSelection records how matchSelection chooses among the installed versions for the go.work or go.mod file.
+func explainSelection(versions []*version.Version, sel *project.Selection, inused string) *version.Explanation {
+	ex := version.Explanation{Query: sel.Version, Candidates: []version.Candidate{}}
+	target := matchSelection(versions, sel, inused)
+	rule := describeSelection(sel)
+	for _, v := range versions {
+		c := version.Candidate{Version: v.Name(), Result: version.CandidateRejected, Reason: "does not satisfy the " + rule}
+		switch {
+		case v.Name() == target && target == inused:
+			c.Result, c.Reason = version.CandidateSelected, fmt.Sprintf("satisfies the %s and is in use", rule)
+		case v.Name() == target:
+			c.Result, c.Reason = version.CandidateSelected, fmt.Sprintf("oldest installed version satisfying the %s", rule)
+		case sel.Allows(v.Name()):
+			c.Reason = fmt.Sprintf("satisfies the %s, but %s is preferred", rule, target)
 		}
-		return nil
+		ex.Candidates = append(ex.Candidates, c)
+	}
+	if target == "" {
+		ex.Error = fmt.Sprintf("no installed version satisfies <%s>", sel.Version)
+	} else {
+		ex.Selected = target
 	}
+	return &ex
+}
 
-	return cli.Exit(wrapstring(fmt.Sprintf("The %q version does not exist, please install it first.", vname)), 1)
+// selectProjectVersion returns the Go version selected by the project files of the working directory.
+func selectProjectVersion() (*project.Selection, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return project.Select(wd)
 }
 
-var goDirectiveReg = regexp.MustCompile(`(?m)^go\s+(\d+\.\d+(?:\.\d+)?(?:beta\d+|rc\d+)?)\s*(?:$|//.*)`)
+// describeSelection describes the project file and directive deciding the version.
+func describeSelection(sel *project.Selection) string {
+	if sel.Directive == "" {
+		return fmt.Sprintf("%q", sel.File)
+	}
+	return fmt.Sprintf("%s directive in %q", sel.Directive, sel.File)
+}
 
-// getGoDirective Extract the go directive from the go.mod file.
-func getGoDirective(goModData []byte) string {
-	// https://go.dev/ref/mod#go-mod-file-go
-	match := goDirectiveReg.FindStringSubmatch(string(goModData))
-	if len(match) > 1 {
-		return match[1]
+// matchSelection returns the installed version satisfying the selection. For the go.work or go.mod file it behaves like the go command:
+// the version in use is kept if it is new enough, otherwise the oldest installed version satisfying the selection is chosen.
+// It returns an empty string if none matches.
+func matchSelection(versions []*version.Version, sel *project.Selection, inused string) string {
+	if sel.Directive == "" {
+		// The version files name a version or a version constraint.
+		target, _ := matchLocalVersion(versions, sel.Version)
+		return target
+	}
+	if sel.Allows(inused) {
+		for i := range versions {
+			if versions[i].Name() == inused {
+				return inused
+			}
+		}
+	}
+	for i := range versions {
+		if sel.Allows(versions[i].Name()) {
+			return versions[i].Name()
+		}
 	}
 	return ""
 }
@@ -20,58 +20,208 @@
 package cli
 
 import (
+	"flag"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli/v2"
+	"github.com/voidint/g/pkg/alias"
+	"github.com/voidint/g/pkg/project"
+	"github.com/voidint/g/version"
 )
 
-func TestGetGoDirective(t *testing.T) {
+func Test_matchSelection(t *testing.T) {
+	versions := []*version.Version{
+		version.MustNew("1.20.14"),
+		version.MustNew("1.21.0"),
+		version.MustNew("1.21.4"),
+		version.MustNew("1.22.3"),
+	}
+
 	tests := []struct {
 		name     string
-		input    []byte
+		sel      *project.Selection
+		inused   string
 		expected string
 	}{
-		{
-			name:     "normal go directive",
-			input:    []byte("module github.com/voidint/g\n\ngo 1.20\n"),
-			expected: "1.20",
-		},
-		{
-			name:     "normal go directive",
-			input:    []byte("module github.com/voidint/g\n\ngo 1.24.0\n"),
-			expected: "1.24.0",
-		},
-		{
-			name:     "normal go directive",
-			input:    []byte("module github.com/voidint/g\n\ngo 1.24.4\n"),
-			expected: "1.24.4",
-		},
-		{
-			name:     "normal go directive",
-			input:    []byte("module github.com/voidint/g\n\ngo 1.25rc1\n"),
-			expected: "1.25rc1",
-		},
-		{
-			name:     "no go directive",
-			input:    []byte("module github.com/voidint/g\n"),
-			expected: "",
-		},
-		{
-			name:     "malformed directive",
-			input:    []byte("go1.20"),
-			expected: "",
-		},
-		{
-			name:     "empty input",
-			input:    []byte(""),
-			expected: "",
-		},
+		{name: "toolchain指令精确匹配", sel: &project.Selection{Directive: project.ToolchainDirective, Version: "1.21.4", Minimum: true}, inused: "1.20.14", expected: "1.21.4"},
+		{name: "当前版本满足toolchain指令", sel: &project.Selection{Directive: project.ToolchainDirective, Version: "1.21.4", Minimum: true}, inused: "1.22.3", expected: "1.22.3"},
+		{name: "toolchain指令未安装", sel: &project.Selection{Directive: project.ToolchainDirective, Version: "1.21.5", Minimum: true}, inused: "1.20.14", expected: "1.22.3"},
+		{name: "没有满足toolchain指令的版本", sel: &project.Selection{Directive: project.ToolchainDirective, Version: "1.23.0", Minimum: true}, inused: "1.22.3", expected: ""},
+		{name: "当前版本满足go指令", sel: &project.Selection{Directive: project.GoDirective, Version: "1.21.0", Minimum: true}, inused: "1.22.3", expected: "1.22.3"},
+		{name: "选择满足go指令的最低版本", sel: &project.Selection{Directive: project.GoDirective, Version: "1.21.1", Minimum: true}, inused: "1.20.14", expected: "1.21.4"},
+		{name: "没有满足go指令的版本", sel: &project.Selection{Directive: project.GoDirective, Version: "1.23.0", Minimum: true}, inused: "1.22.3", expected: ""},
+		{name: "版本文件中的版本约束", sel: &project.Selection{Version: "1.21"}, inused: "1.22.3", expected: "1.21.4"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, matchSelection(versions, tt.sel, tt.inused))
+		})
+	}
+}
+
+func Test_matchLocalVersion(t *testing.T) {
+	versions := []*version.Version{
+		version.MustNew("1.20"), version.MustNew("1.20.14"), version.MustNew("1.21.5"), version.MustNew("1.22rc1"),
 	}
 
+	tests := []struct {
+		name    string
+		vname   string
+		want    string
+		wantErr bool
+	}{
+		{name: "精确版本号", vname: "1.21.5", want: "1.21.5"},
+		{name: "次版本的最新补丁版", vname: "1.20", want: "1.20.14"},
+		{name: "版本约束", vname: "~1.20", want: "1.20.14"},
+		{name: "最新稳定版", vname: version.Stable, want: "1.21.5"},
+		{name: "上一个次版本", vname: version.OldStable, want: "1.20.14"},
+		{name: "最新预发布版", vname: version.Next, want: "1.22rc1"},
+		{name: "未安装的版本", vname: "1.19.x", want: ""},
+		{name: "非法版本约束", vname: "voidint", wantErr: true},
+	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := getGoDirective(tt.input)
-			assert.Equal(t, tt.expected, got)
+			got, err := matchLocalVersion(versions, tt.vname)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
 		})
 	}
 }
+
+func Test_finderOptions(t *testing.T) {
+	dir := t.TempDir()
+	wd, err := os.Getwd()
+	assert.Nil(t, err)
+	assert.Nil(t, os.Chdir(dir))
+	defer os.Chdir(wd)
+	t.Setenv("GOWORK", "off")
+
+	opts, err := finderOptions("1.21.x", true)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(opts))
+
+	_, err = finderOptions(version.MinGo, false)
+	assert.NotNil(t, err)
+
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module x\n\ngo 1.21\n"), 0600))
+	versions := []*version.Version{version.MustNew("1.20.14"), version.MustNew("1.21.0"), version.MustNew("1.21.5")}
+	target, err := matchLocalVersion(versions, version.MinGo)
+	assert.Nil(t, err)
+	assert.Equal(t, "1.21.0", target)
+}
+
+func Test_matchInstalled(t *testing.T) {
+	saved := versionsDir
+	defer func() { versionsDir = saved }()
+	versionsDir = t.TempDir()
+	assert.Nil(t, os.MkdirAll(filepath.Join(versionsDir, "1.21.5"), 0755))
+
+	match := func(vname string) func(versions []*version.Version) (string, error) {
+		return func(versions []*version.Version) (string, error) {
+			return matchLocalVersion(versions, vname)
+		}
+	}
+
+	t.Run("已安装的版本", func(t *testing.T) {
+		target, err := matchInstalled("1.21", true, match("1.21"))
+		assert.Nil(t, err)
+		assert.Equal(t, "1.21.5", target)
+	})
+
+	t.Run("未安装且不自动安装", func(t *testing.T) {
+		target, err := matchInstalled("1.22", false, match("1.22"))
+		assert.Nil(t, err)
+		assert.Equal(t, "", target)
+	})
+
+	t.Run("匹配出错", func(t *testing.T) {
+		_, err := matchInstalled("voidint", true, match("voidint"))
+		assert.NotNil(t, err)
+	})
+}
+
+func Test_suggestInstalled(t *testing.T) {
+	saved := versionsDir
+	defer func() { versionsDir = saved }()
+	versionsDir = t.TempDir()
+	for _, vname := range []string{"1.20.14", "1.21.2", "1.21.5"} {
+		assert.Nil(t, os.MkdirAll(filepath.Join(versionsDir, vname), 0755))
+	}
+
+	t.Run("输入错误的版本号", func(t *testing.T) {
+		assert.Equal(t, []string{"1.21.2", "1.21.5"}, suggestInstalled("1.212"))
+		assert.Equal(t, " Did you mean 1.21.2, 1.21.5?", didYouMean(suggestInstalled("1.212")))
+	})
+
+	t.Run("未安装的次版本", func(t *testing.T) {
+		assert.Equal(t, []string{"1.21.5"}, suggestInstalled("1.22"))
+	})
+
+	t.Run("无建议", func(t *testing.T) {
+		assert.Nil(t, suggestInstalled("~1.22"))
+		assert.Equal(t, "", didYouMean(nil))
+	})
+}
+
+func Test_explainSelection(t *testing.T) {
+	versions := []*version.Version{version.MustNew("1.20.14"), version.MustNew("1.21.0"), version.MustNew("1.21.5")}
+	sel := &project.Selection{File: "go.mod", Directive: project.GoDirective, Version: "1.21.0", Minimum: true}
+
+	ex := explainSelection(versions, sel, "1.21.5")
+	assert.Equal(t, "1.21.5", ex.Selected)
+	assert.Equal(t, []string{version.CandidateRejected, version.CandidateRejected, version.CandidateSelected},
+		[]string{ex.Candidates[0].Result, ex.Candidates[1].Result, ex.Candidates[2].Result})
+	assert.Equal(t, `satisfies the go directive in "go.mod", but 1.21.5 is preferred`, ex.Candidates[1].Reason)
+
+	ex = explainSelection(versions[:1], sel, "")
+	assert.Equal(t, "no installed version satisfies <1.21.0>", ex.Error)
+}
+
+func Test_use(t *testing.T) {
+	savedHome, savedRoot, savedVersions := ghomeDir, goroot, versionsDir
+	defer func() { ghomeDir, goroot, versionsDir = savedHome, savedRoot, savedVersions }()
+
+	rootDir := t.TempDir()
+	ghomeDir = rootDir
+	goroot = filepath.Join(rootDir, "go")
+	versionsDir = filepath.Join(rootDir, "versions")
+	for _, vname := range []string{"1.21.4", "1.22.3"} {
+		assert.Nil(t, os.MkdirAll(filepath.Join(versionsDir, vname), 0755))
+	}
+	assert.Nil(t, alias.Aliases{"team": version.OldStable}.Save(filepath.Join(ghomeDir, aliasesFile)))
+
+	dir := t.TempDir()
+	wd, err := os.Getwd()
+	assert.Nil(t, err)
+	assert.Nil(t, os.Chdir(dir))
+	defer os.Chdir(wd)
+
+	newContext := func(args ...string) *cli.Context {
+		set := flag.NewFlagSet("use", flag.ContinueOnError)
+		set.Bool("local", false, "")
+		set.Bool("install", false, "")
+		set.Bool("explain", false, "")
+		set.String("output", "", "")
+		assert.Nil(t, set.Parse(args))
+		return cli.NewContext(nil, set, nil)
+	}
+
+	t.Run("版本文件记录选择器解析后的具体版本", func(t *testing.T) {
+		assert.Nil(t, use(newContext("--local", version.Stable)))
+		data, err := os.ReadFile(filepath.Join(dir, project.GoVersionFile))
+		assert.Nil(t, err)
+		assert.Equal(t, "1.22.3\n", string(data))
+		assert.Equal(t, "1.22.3", inuse(goroot))
+	})
+
+	t.Run("版本文件记录别名解析后的具体版本", func(t *testing.T) {
+		assert.Nil(t, use(newContext("--local", "team")))
+		data, err := os.ReadFile(filepath.Join(dir, project.GoVersionFile))
+		assert.Nil(t, err)
+		assert.Equal(t, "1.21.4\n", string(data))
+		assert.Equal(t, "1.21.4", inuse(goroot))
+	})
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package cli
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/voidint/g/pkg/errs"
+	"github.com/voidint/g/pkg/manifest"
+	"github.com/voidint/g/version"
+)
+
+func Test_verifyVersion(t *testing.T) {
+	ghomeDir = t.TempDir()
+	versionsDir = filepath.Join(ghomeDir, "versions")
+	downloadsDir = filepath.Join(ghomeDir, "downloads")
+	_ = os.MkdirAll(versionsDir, 0755)
+	_ = os.MkdirAll(downloadsDir, 0755)
+
+	archive := writeGoArchive(t, map[string]string{
+		"go/VERSION":        "go1.99.0",
+		"go/bin/go":         "#!/bin/sh",
+		"go/src/os/file.go": "package os",
+	})
+	assert.Nil(t, installArchive(archive, "1.99.0"))
+
+	t.Run("文件未被修改", func(t *testing.T) {
+		r, err := verifyVersion("1.99.0")
+		assert.Nil(t, err)
+		assert.True(t, r.OK())
+	})
+
+	t.Run("文件被修改", func(t *testing.T) {
+		targetV := filepath.Join(versionsDir, "1.99.0")
+		_ = os.WriteFile(filepath.Join(targetV, "bin", "go"), []byte("#!/bin/bash"), 0755)
+		_ = os.Remove(filepath.Join(targetV, "src", "os", "file.go"))
+		_ = os.MkdirAll(filepath.Join(targetV, "src", "vendor"), 0755)
+		_ = os.WriteFile(filepath.Join(targetV, "src", "vendor", "modules.txt"), nil, 0644)
+
+		r, err := verifyVersion("1.99.0")
+		assert.Nil(t, err)
+		assert.Equal(t, &manifest.Report{
+			Modified: []string{"bin/go"},
+			Missing:  []string{"src/os/file.go"},
+			Extra:    []string{"src/vendor/modules.txt"},
+		}, r)
+
+		var buf bytes.Buffer
+		printVerifyResults(&buf, []*verifyResult{{Version: "1.99.0", Report: r}})
+		assert.Equal(t, "go1.99.0: 1 modified, 1 missing, 1 extra\n"+
+			"    modified: bin/go\n"+
+			"    missing:  src/os/file.go\n"+
+			"    extra:    src/vendor/modules.txt\n", buf.String())
+	})
+
+	t.Run("从缓存的安装包修复", func(t *testing.T) {
+		data, err := os.ReadFile(archive)
+		assert.Nil(t, err)
+		filename, err := packageFile(&version.Package{FileName: fmt.Sprintf("go1.99.0.%s-%s.tar.gz", runtime.GOOS, runtime.GOARCH)})
+		assert.Nil(t, err)
+		assert.Nil(t, os.WriteFile(filename, data, 0644))
+		digest := sha256.Sum256(data)
+		assert.Nil(t, os.WriteFile(filepath.Join(ghomeDir, checksumsFile),
+			[]byte(fmt.Sprintf("%x  %s\n", digest, filepath.Base(filename))), 0644))
+		defer os.Remove(filepath.Join(ghomeDir, checksumsFile))
+
+		assert.Nil(t, repairVersion("1.99.0"))
+
+		r, err := verifyVersion("1.99.0")
+		assert.Nil(t, err)
+		assert.True(t, r.OK())
+
+		entries, err := os.ReadDir(versionsDir)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(entries)) // no staging directory left behind
+	})
+
+	t.Run("不信任未经校验的缓存安装包", func(t *testing.T) {
+		t.Setenv(mirrorEnv, "official|http://127.0.0.1:1/")
+		_ = os.WriteFile(filepath.Join(versionsDir, "1.99.0", "bin", "go"), []byte("#!/bin/bash"), 0755)
+
+		assert.NotNil(t, repairVersion("1.99.0")) // no official checksum offline, and the mirror is unreachable
+
+		r, err := verifyVersion("1.99.0")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"bin/go"}, r.Modified)
+	})
+
+	t.Run("缺少文件清单", func(t *testing.T) {
+		_ = os.MkdirAll(filepath.Join(versionsDir, "1.20.14"), 0755)
+		_, err := verifyVersion("1.20.14")
+		assert.Equal(t, errs.ErrManifestNotFound, err)
+	})
+}
+
+func Test_findExactVersion(t *testing.T) {
+	var items []*version.Version
+	for _, name := range []string{"1.19", "1.19.13", "1.20", "1.20.1", "1.20.14", "1.21.0", "1.21.4"} {
+		v, err := version.New(name)
+		assert.Nil(t, err)
+		items = append(items, v)
+	}
+
+	t.Run("1.21之前的次版本首个发布", func(t *testing.T) {
+		v, err := findExactVersion(items, "1.20")
+		assert.Nil(t, err)
+		assert.Equal(t, "1.20", v.Name())
+
+		v, err = findExactVersion(items, "1.19")
+		assert.Nil(t, err)
+		assert.Equal(t, "1.19", v.Name())
+	})
+
+	t.Run("补丁版本", func(t *testing.T) {
+		v, err := findExactVersion(items, "1.20.1")
+		assert.Nil(t, err)
+		assert.Equal(t, "1.20.1", v.Name())
+	})
+
+	t.Run("版本不存在", func(t *testing.T) {
+		_, err := findExactVersion(items, "1.21")
+		assert.True(t, errs.IsVersionNotFound(err))
+	})
+}
@@ -70,6 +70,11 @@ func (c *Collector) Name() string {
 	return Name
 }
 
+// URL returns the URL of the download page.
+func (c *Collector) URL() string {
+	return c.url
+}
+
 func (c *Collector) loadDocument() (err error) {
 	resp, err := http.Get(c.url)
 	if err != nil {
@@ -118,7 +123,7 @@ func (c *Collector) findGoFileItems() (items []*internal.GoFileItem) {
 
 	anchors.Each(func(j int, anchor *goquery.Selection) {
 		href := anchor.AttrOr("href", "")
-		if !strings.HasPrefix(href, "go") || strings.HasSuffix(href, "/") {
+		if (!strings.HasPrefix(href, "go") && !internal.IsChecksumListFile(href)) || strings.HasSuffix(href, "/") {
 			return
 		}
 
@@ -169,3 +169,10 @@ func TestCollector_Name(t *testing.T) {
 		assert.Equal(t, Name, c.Name())
 	})
 }
+
+func TestCollector_URL(t *testing.T) {
+	t.Run("Download page URL", func(t *testing.T) {
+		c := &Collector{url: "https://example.com/golang/"}
+		assert.Equal(t, "https://example.com/golang/", c.URL())
+	})
+}
@@ -59,6 +59,8 @@ const (
 type Collector interface {
 	// Name Collector name
 	Name() string
+	// URL Download page URL
+	URL() string
 	// StableVersions Return all stable versions
 	StableVersions() (items []*version.Version, err error)
 	// UnstableVersions Return all stable versions
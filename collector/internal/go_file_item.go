@@ -50,8 +50,72 @@ func (item GoFileItem) getGoVersion() string {
 	return arr[0]
 }
 
-func (item GoFileItem) isSHA256File() bool {
-	return strings.HasSuffix(item.FileName, ".sha256")
+// sidecarSuffixes maps the suffix of a per-archive checksum file to its algorithm.
+var sidecarSuffixes = map[string]checksum.Algorithm{
+	".sha256": checksum.SHA256,
+	".sha512": checksum.SHA512,
+	".sha1":   checksum.SHA1,
+	".b2":     checksum.BLAKE2b,
+	".b3":     checksum.BLAKE3,
+}
+
+// sidecarAlgorithm returns the algorithm of the per-archive checksum file (e.g. 'go1.21.4.linux-amd64.tar.gz.sha512').
+func (item GoFileItem) sidecarAlgorithm() (checksum.Algorithm, bool) {
+	for suffix, algo := range sidecarSuffixes {
+		if strings.HasSuffix(item.FileName, suffix) {
+			return algo, true
+		}
+	}
+	return "", false
+}
+
+// checksumListNames maps the name of an aggregate checksum file to its algorithm.
+var checksumListNames = map[string]checksum.Algorithm{
+	"SHA1SUMS":   checksum.SHA1,
+	"SHA256SUMS": checksum.SHA256,
+	"SHA512SUMS": checksum.SHA512,
+	"B2SUMS":     checksum.BLAKE2b,
+	"BLAKE2SUMS": checksum.BLAKE2b,
+	"B3SUMS":     checksum.BLAKE3,
+	"BLAKE3SUMS": checksum.BLAKE3,
+}
+
+// checksumListAlgorithm returns the algorithm of the aggregate checksum file, e.g. 'SHA256SUMS', 'sha256sums.txt'.
+func checksumListAlgorithm(name string) (checksum.Algorithm, bool) {
+	name = strings.ToUpper(strings.TrimSuffix(strings.ToLower(name), ".txt"))
+	if algo, ok := checksumListNames[name]; ok {
+		return algo, true
+	}
+	// e.g. 'SHA256SUM'
+	algo, ok := checksumListNames[name+"S"]
+	return algo, ok
+}
+
+// strongerFirst orders the checksum algorithms from the strongest to the weakest.
+var strongerFirst = []checksum.Algorithm{checksum.SHA512, checksum.BLAKE2b, checksum.BLAKE3, checksum.SHA256, checksum.SHA1}
+
+// sortChecksumLists sorts the aggregate checksum files by the strength of their algorithms, strongest first.
+func sortChecksumLists(lists []version.ChecksumList) {
+	rank := func(algo string) int {
+		for i, a := range strongerFirst {
+			if string(a) == algo {
+				return i
+			}
+		}
+		return len(strongerFirst)
+	}
+	sort.SliceStable(lists, func(i, j int) bool {
+		if ri, rj := rank(lists[i].Algorithm), rank(lists[j].Algorithm); ri != rj {
+			return ri < rj
+		}
+		return lists[i].URL < lists[j].URL
+	})
+}
+
+// IsChecksumListFile reports whether the file is an aggregate checksum file covering many archives (e.g. 'SHA256SUMS').
+func IsChecksumListFile(name string) bool {
+	_, ok := checksumListAlgorithm(name)
+	return ok
 }
 
 func (item GoFileItem) isPackageFile() bool {
@@ -74,6 +138,7 @@ func (item GoFileItem) getKind() version.PackageKind {
 	return "Unknown"
 }
 
+// osMapping maps GOOS to the operating system label of the official download page.
 var osMapping = map[string]string{
 	"linux":     "Linux",
 	"darwin":    "macOS",
@@ -89,44 +154,42 @@ var osMapping = map[string]string{
 }
 
 func (item GoFileItem) getOS() string {
-	for k, v := range osMapping {
-		if strings.Contains(item.FileName, k) {
-			return v
-		}
-	}
-	return ""
+	goos, _ := version.ParsePlatform(item.FileName)
+	return osMapping[goos]
 }
 
+// archMapping maps GOARCH to the architecture label of the official download page.
 var archMapping = map[string]string{
-	"-386.":      "x86",
-	"-amd64.":    "x86-64",
-	"-arm.":      "ARMv6",
-	"-arm64.":    "ARM64",
-	"-armv6l.":   "ARMv6",
-	"-ppc64.":    "ppc64",
-	"-ppc64le.":  "ppc64le",
-	"-mips.":     "mips",
-	"-mipsle.":   "mipsle",
-	"-mips64.":   "mips64",
-	"-mips64le.": "mips64le",
-	"-s390x.":    "s390x",
-	"-riscv64.":  "riscv64",
-	"-loong64.":  "loong64",
+	"386":      "x86",
+	"amd64":    "x86-64",
+	"arm":      "ARMv6",
+	"arm64":    "ARM64",
+	"ppc64":    "ppc64",
+	"ppc64le":  "ppc64le",
+	"mips":     "mips",
+	"mipsle":   "mipsle",
+	"mips64":   "mips64",
+	"mips64le": "mips64le",
+	"s390x":    "s390x",
+	"riscv64":  "riscv64",
+	"loong64":  "loong64",
 }
 
 func (item GoFileItem) getArch() string {
-	for k, v := range archMapping {
-		if strings.Contains(item.FileName, k) {
-			return v
-		}
-	}
-	return ""
+	_, goarch := version.ParsePlatform(item.FileName)
+	return archMapping[goarch]
 }
 
 func Convert2Versions(items []*GoFileItem) (vers []*version.Version, err error) {
 	pkgMap := make(map[string][]*version.Package, 20)
+	var lists []version.ChecksumList
 
 	for _, pitem := range items {
+		if algo, ok := checksumListAlgorithm(pitem.FileName); ok {
+			lists = append(lists, version.ChecksumList{URL: pitem.URL, Algorithm: string(algo)})
+			continue
+		}
+
 		ver := pitem.getGoVersion()
 		if _, ok := pkgMap[ver]; !ok {
 			pkgMap[ver] = make([]*version.Package, 0, 20)
@@ -141,18 +204,36 @@ func Convert2Versions(items []*GoFileItem) (vers []*version.Version, err error)
 				Arch:     pitem.getArch(),
 				Size:     pitem.Size,
 			})
-		} else if pitem.isSHA256File() {
+		} else if algo, ok := pitem.sidecarAlgorithm(); ok {
 			// Set checksum and hashing algorithm.
 			for _, ppkg := range pkgMap[ver] {
 				if !strings.HasPrefix(pitem.FileName, ppkg.FileName) {
 					continue
 				}
-				ppkg.Algorithm = string(checksum.SHA256)
+				ppkg.Algorithm = string(algo)
 				ppkg.ChecksumURL = pitem.URL
 			}
 		}
 	}
 
+	// Packages without a checksum file of their own fall back to the aggregate checksum files of the mirror,
+	// the strongest algorithm first. The entry of each package is looked up by file name when it is verified.
+	if len(lists) > 0 {
+		sortChecksumLists(lists)
+		for _, pkgs := range pkgMap {
+			for _, ppkg := range pkgs {
+				if ppkg.ChecksumURL != "" || ppkg.Checksum != "" {
+					continue
+				}
+				ppkg.Algorithm = lists[0].Algorithm
+				ppkg.ChecksumURL = lists[0].URL
+				if len(lists) > 1 {
+					ppkg.ChecksumLists = lists[1:]
+				}
+			}
+		}
+	}
+
 	vers = make([]*version.Version, 0, len(pkgMap))
 	for vname, pkgs := range pkgMap {
 		v, err := version.New(vname, version.WithPackages(pkgs))
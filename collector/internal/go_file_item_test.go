@@ -23,6 +23,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/voidint/g/pkg/checksum"
 	"github.com/voidint/g/pkg/errs"
 	"github.com/voidint/g/version"
 )
@@ -88,10 +89,11 @@ func Test_getGoVersion(t *testing.T) {
 	})
 }
 
-func Test_isSHA256File(t *testing.T) {
+func Test_sidecarAlgorithm(t *testing.T) {
 	items := []*struct {
-		In       *GoFileItem
-		Expected bool
+		In           *GoFileItem
+		ExpectedAlgo checksum.Algorithm
+		ExpectedOK   bool
 	}{
 		{
 			In: &GoFileItem{
@@ -99,7 +101,7 @@ func Test_isSHA256File(t *testing.T) {
 				URL:      "https://mirrors.aliyun.com/golang/go1.18beta1.darwin-amd64.pkg",
 				Size:     "136.9 MB",
 			},
-			Expected: false,
+			ExpectedOK: false,
 		},
 		{
 			In: &GoFileItem{
@@ -107,32 +109,57 @@ func Test_isSHA256File(t *testing.T) {
 				URL:      "https://mirrors.aliyun.com/golang/go1.4-bootstrap-20170518.tar.gz.sha256",
 				Size:     "64.0 B",
 			},
-			Expected: true,
+			ExpectedAlgo: checksum.SHA256,
+			ExpectedOK:   true,
 		},
 		{
 			In: &GoFileItem{
-				FileName: "go1.4-bootstrap-20170518.tar.gz.sha256",
-				URL:      "https://mirrors.aliyun.com/golang/go1.4-bootstrap-20170518.tar.gz.sha256",
+				FileName: "go1.5.4.darwin-amd64.tar.gz.sha256",
+				URL:      "https://mirrors.aliyun.com/golang/go1.5.4.darwin-amd64.tar.gz.sha256",
 				Size:     "64.0 B",
 			},
-			Expected: true,
+			ExpectedAlgo: checksum.SHA256,
+			ExpectedOK:   true,
 		},
 		{
 			In: &GoFileItem{
-				FileName: "go1.5.4.darwin-amd64.tar.gz.sha256",
-				URL:      "https://mirrors.aliyun.com/golang/go1.5.4.darwin-amd64.tar.gz.sha256",
-				Size:     "64.0 B",
+				FileName: "go1.21.4.linux-amd64.tar.gz.sha512",
+				URL:      "https://example.com/golang/go1.21.4.linux-amd64.tar.gz.sha512",
+				Size:     "128.0 B",
 			},
-			Expected: true,
+			ExpectedAlgo: checksum.SHA512,
+			ExpectedOK:   true,
 		},
 	}
-	t.Run("判断是否是SHA256校验和文件", func(t *testing.T) {
+	t.Run("判断是否是校验和文件", func(t *testing.T) {
 		for _, item := range items {
-			assert.Equal(t, item.Expected, item.In.isSHA256File())
+			algo, ok := item.In.sidecarAlgorithm()
+			assert.Equal(t, item.ExpectedOK, ok)
+			assert.Equal(t, item.ExpectedAlgo, algo)
 		}
 	})
 }
 
+func TestIsChecksumListFile(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{name: "SHA256SUMS", want: true},
+		{name: "SHA512SUMS", want: true},
+		{name: "sha256sums.txt", want: true},
+		{name: "sha256sum.txt", want: true},
+		{name: "B3SUMS", want: true},
+		{name: "go1.21.4.linux-amd64.tar.gz.sha256", want: false},
+		{name: "README.txt", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsChecksumListFile(tt.name))
+		})
+	}
+}
+
 func Test_isPackageFile(t *testing.T) {
 	items := []*struct {
 		In       *GoFileItem
@@ -450,6 +477,13 @@ func Test_getArch(t *testing.T) {
 			},
 			Expected: "loong64",
 		},
+		{
+			In: &GoFileItem{
+				FileName: "go1.4.3.darwin-amd64-osx10.8.pkg",
+				URL:      "https://mirrors.aliyun.com/golang/go1.4.3.darwin-amd64-osx10.8.pkg",
+			},
+			Expected: "x86-64",
+		},
 	}
 	t.Run("从文件名中获取架构", func(t *testing.T) {
 		for _, item := range items {
@@ -545,6 +579,70 @@ func Test_convert2Versions(t *testing.T) {
 		}, vs[2].Packages())
 	})
 
+	t.Run("使用聚合校验和文件", func(t *testing.T) {
+		vs, err := Convert2Versions([]*GoFileItem{
+			{
+				FileName: "go1.21.4.linux-amd64.tar.gz",
+				URL:      "https://example.com/golang/go1.21.4.linux-amd64.tar.gz",
+				Size:     "63.6 MB",
+			},
+			{
+				FileName: "go1.21.4.darwin-arm64.tar.gz",
+				URL:      "https://example.com/golang/go1.21.4.darwin-arm64.tar.gz",
+				Size:     "62.0 MB",
+			},
+			{
+				FileName: "go1.21.4.darwin-arm64.tar.gz.sha512",
+				URL:      "https://example.com/golang/go1.21.4.darwin-arm64.tar.gz.sha512",
+				Size:     "128.0 B",
+			},
+			{
+				FileName: "SHA256SUMS",
+				URL:      "https://example.com/golang/SHA256SUMS",
+				Size:     "8.0 KB",
+			},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(vs))
+
+		pkgs := vs[0].Packages()
+		assert.Equal(t, 2, len(pkgs))
+		assert.Equal(t, "https://example.com/golang/SHA256SUMS", pkgs[0].ChecksumURL)
+		assert.Equal(t, "SHA256", pkgs[0].Algorithm)
+		assert.Equal(t, "https://example.com/golang/go1.21.4.darwin-arm64.tar.gz.sha512", pkgs[1].ChecksumURL)
+		assert.Equal(t, "SHA512", pkgs[1].Algorithm)
+	})
+
+	t.Run("使用多个聚合校验和文件", func(t *testing.T) {
+		vs, err := Convert2Versions([]*GoFileItem{
+			{
+				FileName: "SHA256SUMS",
+				URL:      "https://example.com/golang/SHA256SUMS",
+				Size:     "8.0 KB",
+			},
+			{
+				FileName: "go1.21.4.linux-amd64.tar.gz",
+				URL:      "https://example.com/golang/go1.21.4.linux-amd64.tar.gz",
+				Size:     "63.6 MB",
+			},
+			{
+				FileName: "SHA512SUMS",
+				URL:      "https://example.com/golang/SHA512SUMS",
+				Size:     "16.0 KB",
+			},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(vs))
+
+		pkgs := vs[0].Packages()
+		assert.Equal(t, 1, len(pkgs))
+		assert.Equal(t, "https://example.com/golang/SHA512SUMS", pkgs[0].ChecksumURL)
+		assert.Equal(t, "SHA512", pkgs[0].Algorithm)
+		assert.Equal(t, []version.ChecksumList{
+			{URL: "https://example.com/golang/SHA256SUMS", Algorithm: "SHA256"},
+		}, pkgs[0].ChecksumLists)
+	})
+
 	t.Run("存在无效版本号", func(t *testing.T) {
 		items = append(items, &GoFileItem{
 			FileName: "goa.b.c.linux-amd64.tar.gz",
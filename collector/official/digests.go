@@ -0,0 +1,68 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package official
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/voidint/g/pkg/checksum"
+	"github.com/voidint/g/pkg/errs"
+	httppkg "github.com/voidint/g/pkg/http"
+)
+
+// release is a release in the JSON listing of the official download page.
+type release struct {
+	Files []struct {
+		Filename string `json:"filename"`
+		Sha256   string `json:"sha256"`
+	} `json:"files"`
+}
+
+// Digests returns the SHA-256 digests of the packages of all releases keyed by file name,
+// read from the JSON listing of the official download page (e.g. 'https://go.dev/dl/?mode=json&include=all').
+func Digests(downloadPageURL string) (digests map[string]string, err error) {
+	if downloadPageURL == "" {
+		return nil, errs.ErrEmptyURL
+	}
+	url := strings.TrimSuffix(downloadPageURL, "/") + "/?mode=json&include=all"
+
+	data, err := httppkg.DownloadAsBytes(url)
+	if err != nil {
+		return nil, err
+	}
+
+	var releases []release
+	if err = json.Unmarshal(data, &releases); err != nil {
+		return nil, errors.Wrapf(err, "%q", url)
+	}
+
+	digests = make(map[string]string, 4096)
+	for _, r := range releases {
+		for _, f := range r.Files {
+			if f.Filename == "" || len(f.Sha256) != checksum.SHA256.Size()*2 {
+				continue
+			}
+			digests[f.Filename] = strings.ToLower(f.Sha256)
+		}
+	}
+	return digests, nil
+}
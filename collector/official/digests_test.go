@@ -0,0 +1,66 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package official
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/voidint/g/pkg/errs"
+)
+
+func TestDigests(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/dl/" || r.URL.Query().Get("mode") != "json" || r.URL.Query().Get("include") != "all" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`[
+	{"version": "go1.21.4", "stable": true, "files": [
+		{"filename": "go1.21.4.linux-amd64.tar.gz", "os": "linux", "arch": "amd64", "sha256": "73CAC0215254D0C7D1241FA40837851F3B9A8A742D0B54714CBDFB3FEAF8F0AF", "kind": "archive"},
+		{"filename": "go1.21.4.src.tar.gz", "os": "", "arch": "", "sha256": "", "kind": "source"}
+	]},
+	{"version": "go1.2.2", "stable": true, "files": [
+		{"filename": "go1.2.2.linux-amd64.tar.gz", "os": "linux", "arch": "amd64", "sha256": "6bd151ca49c435462c8bf019477a6244b958ebb5", "kind": "archive"}
+	]}
+]`))
+	}))
+	defer srv.Close()
+
+	t.Run("读取官方下载页面的JSON列表", func(t *testing.T) {
+		digests, err := Digests(srv.URL + "/dl")
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]string{
+			"go1.21.4.linux-amd64.tar.gz": "73cac0215254d0c7d1241fa40837851f3b9a8a742d0b54714cbdfb3feaf8f0af",
+		}, digests)
+	})
+
+	t.Run("空URL", func(t *testing.T) {
+		_, err := Digests("")
+		assert.Equal(t, errs.ErrEmptyURL, err)
+	})
+
+	t.Run("页面不存在", func(t *testing.T) {
+		_, err := Digests(srv.URL + "/404/")
+		assert.True(t, errs.IsURLUnreachable(err))
+	})
+}
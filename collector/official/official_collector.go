@@ -70,6 +70,11 @@ func (c *Collector) Name() string {
 	return Name
 }
 
+// URL returns the URL of the download page.
+func (c *Collector) URL() string {
+	return c.url
+}
+
 func (c *Collector) loadDocument() (err error) {
 	resp, err := http.Get(c.url)
 	if err != nil {
@@ -269,6 +269,25 @@ func TestAllVersions(t *testing.T) {
 		assert.Equal(t, "1.13beta1", items[len(items)-1].Name())
 		assert.Equal(t, 15, len(items[len(items)-1].Packages()))
 	})
+
+	t.Run("安装包的操作系统和架构与文件名一致", func(t *testing.T) {
+		c, err := getCollector()
+		assert.Nil(t, err)
+		assert.NotNil(t, c)
+
+		items, err := c.AllVersions()
+		assert.Nil(t, err)
+		for _, item := range items {
+			for _, pkg := range item.Packages() {
+				if pkg.Kind == version.SourceKind {
+					continue
+				}
+				goos, goarch := version.ParsePlatform(pkg.FileName)
+				assert.Equal(t, goos, pkg.Goos(), pkg.FileName)
+				assert.Equal(t, goarch, pkg.Goarch(), pkg.FileName)
+			}
+		}
+	})
 }
 
 func TestNewCollector(t *testing.T) {
@@ -350,3 +369,10 @@ func TestCollector_Name(t *testing.T) {
 		assert.Equal(t, Name, c.Name())
 	})
 }
+
+func TestCollector_URL(t *testing.T) {
+	t.Run("Download page URL", func(t *testing.T) {
+		c := &Collector{url: "https://example.com/golang/"}
+		assert.Equal(t, "https://example.com/golang/", c.URL())
+	})
+}
@@ -55,9 +55,22 @@ function setPath() {
     }
 }
 
+# 'g shell' switches the version of the current session only, its output is evaluated by the 'g' function of the profile.
+function setProfile() {
+    $wrapper = "function g { if (`$args[0] -eq 'shell') { g.exe shell --shell pwsh @(`$args | Select-Object -Skip 1) | Out-String | Invoke-Expression } else { g.exe @args } }"
+    if ((Test-Path -Path $PROFILE) -and (Select-String -Path $PROFILE -SimpleMatch -Quiet -Pattern "g.exe shell --shell pwsh")) {
+        Write-Output "g shell setup already exists in $PROFILE"
+        return
+    }
+    New-Item -Force -Path (Split-Path -Parent $PROFILE) -ItemType "directory" | Out-Null
+    Add-Content -Path $PROFILE -Value "`n# g shell setup`n$wrapper"
+    Write-Host -ForegroundColor Green "g shell setup appended to $PROFILE"
+}
+
 function SetEnv () {
     setHOME
     setPath
+    setProfile
 }
 
 Write-Host -ForegroundColor Blue "[1/3] Downloading ${url}"
@@ -61,6 +61,15 @@ export GOROOT="${HOME}/.g/go"
 [ -z "$GOPATH" ] && export GOPATH="${HOME}/go"
 export PATH="${HOME}/.g/bin:${GOROOT}/bin:${GOPATH}/bin:$PATH"
 export G_MIRROR=https://golang.google.cn/dl/
+# 'g shell' switches the version of the current session only
+g() {
+    if [ "$1" = "shell" ]; then
+        shift
+        eval "$(command g shell --shell "$([ -n "$ZSH_VERSION" ] && echo zsh || echo bash)" "$@")"
+    else
+        command g "$@"
+    fi
+}
 	EOF
 
     if [ -x "$(command -v bash)" ]; then
@@ -96,6 +105,15 @@ set -gx GOPATH "$HOME/go"
 if set -q GOPATH;
     fish_add_path "$GOPATH/bin"
 end
+
+# 'g shell' switches the version of the current session only
+function g --wraps g
+    if test "$argv[1]" = shell
+        command g shell --shell fish $argv[2..-1] | source
+    else
+        command g $argv
+    end
+end
 EOF_ENV_FISH
 
         # Configure fish to source env.fish
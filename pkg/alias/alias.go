@@ -0,0 +1,136 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// Package alias manages the user-defined names of Go versions, e.g. 'work' for '1.21.4'.
+package alias
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/voidint/g/version"
+)
+
+// Alias is a name of a version or a version constraint.
+type Alias struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// Aliases maps the names to the versions or version constraints.
+type Aliases map[string]string
+
+var nameReg = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_.-]*$`)
+
+// reserved are the names with a meaning of their own when selecting versions.
+var reserved = map[string]bool{
+	version.Latest:    true,
+	version.Stable:    true,
+	version.OldStable: true,
+	version.Next:      true,
+	version.MinGo:     true,
+}
+
+// ValidateName checks that the name can be told apart from the versions and version constraints.
+func ValidateName(name string) error {
+	if !nameReg.MatchString(name) {
+		return fmt.Errorf("invalid alias name %q, it must start with a letter and only contain letters, digits, '_', '.' and '-'", name)
+	}
+	if reserved[name] || ValidateVersion(name) == nil {
+		return fmt.Errorf("alias name %q is reserved or looks like a version", name)
+	}
+	return nil
+}
+
+// ValidateVersion checks that the alias names a version or a version constraint.
+func ValidateVersion(vname string) error {
+	if version.IsSelector(vname) {
+		return nil
+	}
+	if _, err := version.Semantify(vname); err == nil {
+		return nil
+	}
+	if _, err := version.NewConstraint(vname); err != nil {
+		return fmt.Errorf("invalid version or version constraint %q", vname)
+	}
+	return nil
+}
+
+// Load reads the aliases from the file. A missing file holds no aliases.
+func Load(filename string) (Aliases, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return make(Aliases), nil
+		}
+		return nil, errors.WithStack(err)
+	}
+	as := make(Aliases)
+	if err = json.Unmarshal(data, &as); err != nil {
+		return nil, errors.Wrapf(err, "malformed alias file %q", filename)
+	}
+	return as, nil
+}
+
+// Save writes the aliases to the file atomically.
+func (as Aliases) Save(filename string) error {
+	data, err := json.MarshalIndent(as, "", "    ")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(filename), filepath.Base(filename)+".*")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(append(data, '\n')); err != nil {
+		_ = tmp.Close()
+		return errors.WithStack(err)
+	}
+	if err = tmp.Close(); err != nil {
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(os.Rename(tmp.Name(), filename))
+}
+
+// Resolve returns the version or version constraint of the alias, or the name itself if it is not an alias.
+func (as Aliases) Resolve(name string) (vname string, ok bool) {
+	if vname, ok = as[name]; ok {
+		return vname, true
+	}
+	return name, false
+}
+
+// List returns the aliases ordered by name.
+func (as Aliases) List() []Alias {
+	items := make([]Alias, 0, len(as))
+	for name, vname := range as {
+		items = append(items, Alias{Name: name, Version: vname})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Name < items[j].Name
+	})
+	return items
+}
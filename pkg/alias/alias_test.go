@@ -0,0 +1,77 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package alias
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateName(t *testing.T) {
+	for _, name := range []string{"work", "legacy-1.20", "Prod_2"} {
+		assert.Nil(t, ValidateName(name), name)
+	}
+	for _, name := range []string{"", "1.21", "v1", "x", "latest", "stable", "oldstable", "next", "min-go", "-a", "a b"} {
+		assert.NotNil(t, ValidateName(name), name)
+	}
+}
+
+func TestValidateVersion(t *testing.T) {
+	for _, vname := range []string{"1.21.4", "1.21rc1", "~1.21", ">= 1.20, < 1.22", "latest", "oldstable", "next", "min-go", "go1.21.4", "1.20.x || 1.22.x", ">=1.21, !=1.21.2"} {
+		assert.Nil(t, ValidateVersion(vname), vname)
+	}
+	assert.NotNil(t, ValidateVersion("abc"))
+}
+
+func TestAliases(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "aliases.json")
+
+	t.Run("文件不存在时没有别名", func(t *testing.T) {
+		as, err := Load(filename)
+		assert.Nil(t, err)
+		assert.Empty(t, as)
+	})
+
+	t.Run("保存并重新加载", func(t *testing.T) {
+		as := Aliases{"work": "1.21.4", "legacy": "1.20"}
+		assert.Nil(t, as.Save(filename))
+
+		loaded, err := Load(filename)
+		assert.Nil(t, err)
+		assert.Equal(t, as, loaded)
+		assert.Equal(t, []Alias{{Name: "legacy", Version: "1.20"}, {Name: "work", Version: "1.21.4"}}, loaded.List())
+
+		vname, ok := loaded.Resolve("work")
+		assert.True(t, ok)
+		assert.Equal(t, "1.21.4", vname)
+		vname, ok = loaded.Resolve("1.22.0")
+		assert.False(t, ok)
+		assert.Equal(t, "1.22.0", vname)
+	})
+
+	t.Run("格式错误的文件", func(t *testing.T) {
+		assert.Nil(t, os.WriteFile(filename, []byte("{"), 0600))
+		_, err := Load(filename)
+		assert.NotNil(t, err)
+	})
+}
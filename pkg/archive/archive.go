@@ -0,0 +1,443 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/voidint/g/pkg/errs"
+)
+
+// DefaultMaxSize is the default cap on the total uncompressed size of an archive.
+// Go distributions unpack to a few hundred megabytes, so 2GiB leaves plenty of headroom.
+const DefaultMaxSize int64 = 2 << 30
+
+// Extractor unpacks Go distribution archives while rejecting unsafe entries.
+type Extractor struct {
+	maxSize int64
+}
+
+// WithMaxSize sets the cap on the total uncompressed size of an archive.
+func WithMaxSize(size int64) func(ex *Extractor) {
+	return func(ex *Extractor) {
+		ex.maxSize = size
+	}
+}
+
+// NewExtractor creates an Extractor instance with applied options.
+func NewExtractor(opts ...func(ex *Extractor)) *Extractor {
+	ex := Extractor{
+		maxSize: DefaultMaxSize,
+	}
+	for _, setter := range opts {
+		if setter == nil {
+			continue
+		}
+		setter(&ex)
+	}
+	return &ex
+}
+
+// Extract unpacks the .tar.gz or .zip archive into dstDir and returns the path of its single root directory.
+//
+// Entries with absolute paths, '..' traversal or symbolic links pointing outside the root directory are rejected,
+// as are archives holding more than one top-level entry or exceeding the maximum uncompressed size.
+func (ex *Extractor) Extract(filename, dstDir string) (rootDir string, err error) {
+	switch {
+	case strings.HasSuffix(filename, ".tar.gz"), strings.HasSuffix(filename, ".tgz"):
+		return ex.extractTarGz(filename, dstDir)
+	case strings.HasSuffix(filename, ".zip"):
+		return ex.extractZip(filename, dstDir)
+	default:
+		return "", errs.ErrUnsupportedArchiveFormat
+	}
+}
+
+// UncompressedSize estimates the number of bytes the archive occupies once extracted.
+//
+// For .tar.gz archives the size is taken from the gzip trailer, which is exact for
+// single member streams below 4GiB (as Go distributions are) and never requires decompression.
+func UncompressedSize(filename string) (size uint64, err error) {
+	switch {
+	case strings.HasSuffix(filename, ".tar.gz"), strings.HasSuffix(filename, ".tgz"):
+		f, err := os.Open(filename)
+		if err != nil {
+			return 0, errors.WithStack(err)
+		}
+		defer f.Close()
+
+		trailer := make([]byte, 4)
+		if _, err = f.Seek(-4, io.SeekEnd); err != nil {
+			return 0, errs.NewMalformedArchiveError(filepath.Base(filename), err.Error())
+		}
+		if _, err = io.ReadFull(f, trailer); err != nil {
+			return 0, errs.NewMalformedArchiveError(filepath.Base(filename), err.Error())
+		}
+		return uint64(binary.LittleEndian.Uint32(trailer)), nil
+
+	case strings.HasSuffix(filename, ".zip"):
+		zr, err := zip.OpenReader(filename)
+		if err != nil {
+			return 0, errs.NewMalformedArchiveError(filepath.Base(filename), err.Error())
+		}
+		defer zr.Close()
+
+		for _, zf := range zr.File {
+			size += zf.UncompressedSize64
+		}
+		return size, nil
+
+	default:
+		return 0, errs.ErrUnsupportedArchiveFormat
+	}
+}
+
+// Extract unpacks the archive with the default Extractor.
+func Extract(filename, dstDir string) (rootDir string, err error) {
+	return NewExtractor().Extract(filename, dstDir)
+}
+
+type entryKind uint8
+
+const (
+	dirEntry entryKind = iota
+	fileEntry
+	symlinkEntry
+	hardlinkEntry
+)
+
+// entry is the format independent view of an archive member.
+type entry struct {
+	name     string
+	kind     entryKind
+	mode     fs.FileMode
+	linkname string
+	open     func() (io.ReadCloser, error)
+}
+
+// writer materializes validated entries below dstDir.
+type writer struct {
+	dstDir  string
+	root    string
+	written int64
+	maxSize int64
+	// symlinks holds the slash separated names of the symbolic links extracted so far.
+	symlinks map[string]bool
+	// traversed holds the slash separated paths that the targets of the extracted symbolic links go through.
+	traversed map[string]bool
+}
+
+func (ex *Extractor) extractTarGz(filename, dstDir string) (rootDir string, err error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	defer f.Close()
+
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		return "", errs.NewMalformedArchiveError(filepath.Base(filename), err.Error())
+	}
+	defer gr.Close()
+
+	w := writer{dstDir: dstDir, maxSize: ex.maxSize}
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", errs.NewMalformedArchiveError(filepath.Base(filename), err.Error())
+		}
+
+		e := entry{
+			name:     hdr.Name,
+			mode:     hdr.FileInfo().Mode(),
+			linkname: hdr.Linkname,
+			open: func() (io.ReadCloser, error) {
+				return io.NopCloser(tr), nil
+			},
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			e.kind = dirEntry
+		case tar.TypeReg:
+			e.kind = fileEntry
+		case tar.TypeSymlink:
+			e.kind = symlinkEntry
+		case tar.TypeLink:
+			e.kind = hardlinkEntry
+		case tar.TypeXGlobalHeader, tar.TypeXHeader:
+			continue
+		default:
+			return "", errs.NewMalformedArchiveError(hdr.Name, fmt.Sprintf("unsupported entry type %q", hdr.Typeflag))
+		}
+		if err = w.write(&e); err != nil {
+			return "", err
+		}
+	}
+	return w.rootDir()
+}
+
+func (ex *Extractor) extractZip(filename, dstDir string) (rootDir string, err error) {
+	zr, err := zip.OpenReader(filename)
+	if err != nil {
+		return "", errs.NewMalformedArchiveError(filepath.Base(filename), err.Error())
+	}
+	defer zr.Close()
+
+	w := writer{dstDir: dstDir, maxSize: ex.maxSize}
+	for _, zf := range zr.File {
+		zf := zf
+		mode := zf.Mode()
+		e := entry{
+			name: zf.Name,
+			mode: mode,
+			open: zf.Open,
+		}
+		switch {
+		case mode.IsDir():
+			e.kind = dirEntry
+		case mode.IsRegular():
+			e.kind = fileEntry
+		case mode&fs.ModeSymlink != 0:
+			e.kind = symlinkEntry
+			if e.linkname, err = readLinkname(zf); err != nil {
+				return "", errs.NewMalformedArchiveError(zf.Name, err.Error())
+			}
+		default:
+			return "", errs.NewMalformedArchiveError(zf.Name, fmt.Sprintf("unsupported entry type %q", mode.Type()))
+		}
+		if err = w.write(&e); err != nil {
+			return "", err
+		}
+	}
+	return w.rootDir()
+}
+
+// readLinkname reads the symbolic link target stored as the content of a zip entry.
+func readLinkname(zf *zip.File) (string, error) {
+	rc, err := zf.Open()
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(io.LimitReader(rc, 4096))
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// cleanName normalizes the entry name and rejects absolute or escaping paths.
+func cleanName(name string) (string, error) {
+	name = strings.ReplaceAll(name, `\`, "/")
+	if strings.HasPrefix(name, "/") || filepath.IsAbs(name) || filepath.VolumeName(name) != "" {
+		return "", errs.NewMalformedArchiveError(name, "absolute path is not allowed")
+	}
+	cleaned := path.Clean(name)
+	if cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", errs.NewMalformedArchiveError(name, "path escapes the target directory")
+	}
+	return cleaned, nil
+}
+
+// within reports whether the slash separated name lies inside the root directory.
+func within(root, name string) bool {
+	return name == root || strings.HasPrefix(name, root+"/")
+}
+
+func (w *writer) write(e *entry) (err error) {
+	name, err := cleanName(e.name)
+	if err != nil {
+		return err
+	}
+	if name == "." {
+		return nil
+	}
+
+	top := strings.SplitN(name, "/", 2)[0]
+	if w.root == "" {
+		w.root = top
+	} else if top != w.root {
+		return errs.NewMalformedArchiveError(e.name, fmt.Sprintf("archive must contain a single root directory, found %q and %q", w.root, top))
+	}
+	if name == w.root && e.kind != dirEntry {
+		return errs.NewMalformedArchiveError(e.name, "root entry must be a directory")
+	}
+
+	// Symbolic links already on disk are never followed, so entries never go through them.
+	if err = w.checkSymlinks(e.name, name); err != nil {
+		return err
+	}
+	target := filepath.Join(w.dstDir, filepath.FromSlash(name))
+
+	switch e.kind {
+	case dirEntry:
+		return errors.WithStack(os.MkdirAll(target, 0755))
+
+	case symlinkEntry, hardlinkEntry:
+		linkname := strings.ReplaceAll(e.linkname, `\`, "/")
+		if linkname == "" || path.IsAbs(linkname) || filepath.IsAbs(linkname) {
+			return errs.NewMalformedArchiveError(e.name, fmt.Sprintf("link target %q is not a relative path", e.linkname))
+		}
+		var resolved string
+		if e.kind == symlinkEntry {
+			resolved = path.Join(path.Dir(name), linkname) // symbolic links are relative to their parent directory
+		} else {
+			resolved = path.Clean(linkname) // hard links are relative to the archive root
+		}
+		if !within(w.root, resolved) {
+			return errs.NewMalformedArchiveError(e.name, fmt.Sprintf("link target %q escapes the root directory", e.linkname))
+		}
+		if e.kind == symlinkEntry {
+			if err = w.checkSymlinkTarget(e.name, name, linkname); err != nil {
+				return err
+			}
+		}
+		if err = os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return errors.WithStack(err)
+		}
+		if e.kind == symlinkEntry {
+			return errors.WithStack(os.Symlink(filepath.FromSlash(linkname), target))
+		}
+		if err = w.checkSymlinks(e.linkname, resolved); err != nil {
+			return err
+		}
+		return errors.WithStack(os.Link(filepath.Join(w.dstDir, filepath.FromSlash(resolved)), target))
+
+	default:
+		if err = os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return errors.WithStack(err)
+		}
+		return w.writeFile(e, target)
+	}
+}
+
+// checkSymlinks rejects the slash separated name if it, or any of its parent directories, is a symbolic link already extracted.
+func (w *writer) checkSymlinks(entryName, name string) error {
+	p := w.dstDir
+	parts := strings.Split(name, "/")
+	for i := range parts {
+		p = filepath.Join(p, parts[i])
+		fi, err := os.Lstat(p)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if fi.Mode()&fs.ModeSymlink != 0 {
+			return errs.NewMalformedArchiveError(entryName, fmt.Sprintf("path goes through the symbolic link %q", strings.Join(parts[:i+1], "/")))
+		}
+	}
+	return nil
+}
+
+// checkSymlinkTarget rejects the symbolic link at the slash separated name if its target goes through a symbolic link
+// extracted before, or if the target of a symbolic link extracted before goes through the name. Targets are only checked
+// lexically, which holds as long as no link on the way is resolved, e.g. 'go/a -> d/b/..' escapes the root directory once
+// 'go/d/b -> ..' exists. A target may still name a symbolic link as its last element, since that link is checked in turn.
+func (w *writer) checkSymlinkTarget(entryName, name, linkname string) error {
+	if w.traversed[name] {
+		return errs.NewMalformedArchiveError(entryName, "the target of a symbolic link extracted before goes through the path")
+	}
+
+	parts := strings.Split(path.Dir(name), "/")
+	var traversed []string
+	var last string // the element just visited, which is only gone through if the target continues
+	for _, elem := range strings.Split(linkname, "/") {
+		if elem == "" || elem == "." {
+			continue
+		}
+		if last != "" {
+			if w.symlinks[last] {
+				return errs.NewMalformedArchiveError(entryName, fmt.Sprintf("link target %q goes through the symbolic link %q", linkname, last))
+			}
+			traversed = append(traversed, last)
+		}
+		if elem == ".." {
+			if len(parts) > 0 {
+				parts = parts[:len(parts)-1]
+			}
+			last = ""
+			continue
+		}
+		parts = append(parts, elem)
+		last = strings.Join(parts, "/")
+	}
+
+	if w.symlinks == nil {
+		w.symlinks = make(map[string]bool)
+		w.traversed = make(map[string]bool)
+	}
+	w.symlinks[name] = true
+	for _, p := range traversed {
+		w.traversed[p] = true
+	}
+	return nil
+}
+
+func (w *writer) writeFile(e *entry, target string) (err error) {
+	rc, err := e.open()
+	if err != nil {
+		return errs.NewMalformedArchiveError(e.name, err.Error())
+	}
+	defer rc.Close()
+
+	// Keep the permission bits (notably the executable ones) but drop setuid/setgid/sticky bits.
+	perm := e.mode.Perm() | 0600
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, perm)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer f.Close()
+
+	remaining := w.maxSize - w.written
+	n, err := io.CopyN(f, rc, remaining+1)
+	w.written += n
+	if err != nil && err != io.EOF {
+		return errs.NewMalformedArchiveError(e.name, err.Error())
+	}
+	if n > remaining {
+		return errs.ErrArchiveTooLarge
+	}
+	// The umask may have stripped bits from the requested permissions.
+	return errors.WithStack(os.Chmod(target, perm))
+}
+
+func (w *writer) rootDir() (string, error) {
+	if w.root == "" {
+		return "", errs.ErrEmptyArchive
+	}
+	return filepath.Join(w.dstDir, w.root), nil
+}
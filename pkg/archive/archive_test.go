@@ -0,0 +1,303 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/voidint/g/pkg/errs"
+)
+
+type testEntry struct {
+	name     string
+	body     string
+	mode     int64
+	typeflag byte
+	linkname string
+}
+
+func writeTarGz(t *testing.T, entries []testEntry) string {
+	filename := filepath.Join(t.TempDir(), "go.tar.gz")
+	f, err := os.Create(filename)
+	assert.Nil(t, err)
+	defer f.Close()
+
+	gw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gw)
+	for _, e := range entries {
+		hdr := tar.Header{
+			Name:     e.name,
+			Mode:     e.mode,
+			Typeflag: e.typeflag,
+			Linkname: e.linkname,
+			Size:     int64(len(e.body)),
+		}
+		assert.Nil(t, tw.WriteHeader(&hdr))
+		_, err = tw.Write([]byte(e.body))
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, tw.Close())
+	assert.Nil(t, gw.Close())
+	return filename
+}
+
+func writeZip(t *testing.T, entries []testEntry) string {
+	filename := filepath.Join(t.TempDir(), "go.zip")
+	f, err := os.Create(filename)
+	assert.Nil(t, err)
+	defer f.Close()
+
+	zw := zip.NewWriter(f)
+	for _, e := range entries {
+		hdr := zip.FileHeader{Name: e.name}
+		switch e.typeflag {
+		case tar.TypeDir:
+			hdr.SetMode(os.ModeDir | 0755)
+		case tar.TypeSymlink:
+			hdr.SetMode(os.ModeSymlink | 0777)
+			e.body = e.linkname
+		default:
+			hdr.SetMode(os.FileMode(e.mode))
+		}
+		w, err := zw.CreateHeader(&hdr)
+		assert.Nil(t, err)
+		_, err = w.Write([]byte(e.body))
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, zw.Close())
+	return filename
+}
+
+func TestExtractor_Extract(t *testing.T) {
+	goodEntries := []testEntry{
+		{name: "go/", mode: 0755, typeflag: tar.TypeDir},
+		{name: "go/VERSION", body: "go1.21.4", mode: 0644, typeflag: tar.TypeReg},
+		{name: "go/bin/go", body: "#!/bin/sh", mode: 0755, typeflag: tar.TypeReg},
+		{name: "go/misc/link", mode: 0777, typeflag: tar.TypeSymlink, linkname: "../VERSION"},
+		{name: "go/misc/link2", mode: 0777, typeflag: tar.TypeSymlink, linkname: "./link"},
+	}
+
+	for _, gen := range []struct {
+		name  string
+		write func(*testing.T, []testEntry) string
+	}{
+		{name: "tar.gz", write: writeTarGz},
+		{name: "zip", write: writeZip},
+	} {
+		t.Run(gen.name+" 正常解压", func(t *testing.T) {
+			dstDir := t.TempDir()
+			rootDir, err := NewExtractor().Extract(gen.write(t, goodEntries), dstDir)
+			assert.Nil(t, err)
+			assert.Equal(t, filepath.Join(dstDir, "go"), rootDir)
+
+			data, err := os.ReadFile(filepath.Join(rootDir, "VERSION"))
+			assert.Nil(t, err)
+			assert.Equal(t, "go1.21.4", string(data))
+
+			fi, err := os.Stat(filepath.Join(rootDir, "bin", "go"))
+			assert.Nil(t, err)
+			assert.Equal(t, os.FileMode(0755), fi.Mode().Perm())
+
+			linkname, err := os.Readlink(filepath.Join(rootDir, "misc", "link"))
+			assert.Nil(t, err)
+			assert.Equal(t, filepath.FromSlash("../VERSION"), linkname)
+		})
+
+		t.Run(gen.name+" 根目录可以为任意名称", func(t *testing.T) {
+			dstDir := t.TempDir()
+			rootDir, err := Extract(gen.write(t, []testEntry{
+				{name: "go1.21.4/bin/go", body: "#!/bin/sh", mode: 0755, typeflag: tar.TypeReg},
+			}), dstDir)
+			assert.Nil(t, err)
+			assert.Equal(t, filepath.Join(dstDir, "go1.21.4"), rootDir)
+		})
+
+		tests := []struct {
+			name    string
+			entries []testEntry
+			check   func(error) bool
+		}{
+			{
+				name:    "绝对路径",
+				entries: []testEntry{{name: "/go/bin/go", body: "x", mode: 0755, typeflag: tar.TypeReg}},
+				check:   errs.IsMalformedArchive,
+			},
+			{
+				name:    "路径穿越",
+				entries: []testEntry{{name: "go/../../evil", body: "x", mode: 0644, typeflag: tar.TypeReg}},
+				check:   errs.IsMalformedArchive,
+			},
+			{
+				name:    "反斜杠路径穿越",
+				entries: []testEntry{{name: `go\..\..\evil`, body: "x", mode: 0644, typeflag: tar.TypeReg}},
+				check:   errs.IsMalformedArchive,
+			},
+			{
+				name: "符号链接指向根目录之外",
+				entries: []testEntry{
+					{name: "go/", mode: 0755, typeflag: tar.TypeDir},
+					{name: "go/evil", mode: 0777, typeflag: tar.TypeSymlink, linkname: "../../etc/passwd"},
+				},
+				check: errs.IsMalformedArchive,
+			},
+			{
+				name: "符号链接为绝对路径",
+				entries: []testEntry{
+					{name: "go/", mode: 0755, typeflag: tar.TypeDir},
+					{name: "go/evil", mode: 0777, typeflag: tar.TypeSymlink, linkname: "/etc/passwd"},
+				},
+				check: errs.IsMalformedArchive,
+			},
+			{
+				name: "经由已解压的符号链接写入根目录之外",
+				entries: []testEntry{
+					{name: "go/", mode: 0755, typeflag: tar.TypeDir},
+					{name: "go/d/b", mode: 0777, typeflag: tar.TypeSymlink, linkname: ".."},
+					{name: "go/a", mode: 0777, typeflag: tar.TypeSymlink, linkname: "d/b/.."},
+					{name: "go/a/evil", body: "x", mode: 0644, typeflag: tar.TypeReg},
+				},
+				check: errs.IsMalformedArchive,
+			},
+			{
+				name: "经由符号链接链写入更上层目录",
+				entries: []testEntry{
+					{name: "go/", mode: 0755, typeflag: tar.TypeDir},
+					{name: "go/d/b", mode: 0777, typeflag: tar.TypeSymlink, linkname: ".."},
+					{name: "go/a", mode: 0777, typeflag: tar.TypeSymlink, linkname: "d/b/.."},
+					{name: "go/c", mode: 0777, typeflag: tar.TypeSymlink, linkname: "a/.."},
+					{name: "go/c/evil", body: "x", mode: 0644, typeflag: tar.TypeReg},
+				},
+				check: errs.IsMalformedArchive,
+			},
+			{
+				name: "符号链接链指向根目录之外",
+				entries: []testEntry{
+					{name: "go/", mode: 0755, typeflag: tar.TypeDir},
+					{name: "go/d/b", mode: 0777, typeflag: tar.TypeSymlink, linkname: ".."},
+					{name: "go/a", mode: 0777, typeflag: tar.TypeSymlink, linkname: "d/b/.."},
+				},
+				check: errs.IsMalformedArchive,
+			},
+			{
+				name: "先解压经由后续符号链接的符号链接",
+				entries: []testEntry{
+					{name: "go/", mode: 0755, typeflag: tar.TypeDir},
+					{name: "go/a", mode: 0777, typeflag: tar.TypeSymlink, linkname: "d/b/.."},
+					{name: "go/d/b", mode: 0777, typeflag: tar.TypeSymlink, linkname: ".."},
+				},
+				check: errs.IsMalformedArchive,
+			},
+			{
+				name: "覆盖已解压的符号链接",
+				entries: []testEntry{
+					{name: "go/", mode: 0755, typeflag: tar.TypeDir},
+					{name: "go/d/b", mode: 0777, typeflag: tar.TypeSymlink, linkname: ".."},
+					{name: "go/a", mode: 0777, typeflag: tar.TypeSymlink, linkname: "d/b/../evil"},
+					{name: "go/a", body: "x", mode: 0644, typeflag: tar.TypeReg},
+				},
+				check: errs.IsMalformedArchive,
+			},
+			{
+				name: "多个根目录",
+				entries: []testEntry{
+					{name: "go/VERSION", body: "x", mode: 0644, typeflag: tar.TypeReg},
+					{name: "other/VERSION", body: "x", mode: 0644, typeflag: tar.TypeReg},
+				},
+				check: errs.IsMalformedArchive,
+			},
+			{
+				name:    "根条目不是目录",
+				entries: []testEntry{{name: "VERSION", body: "x", mode: 0644, typeflag: tar.TypeReg}},
+				check:   errs.IsMalformedArchive,
+			},
+			{
+				name:    "空压缩包",
+				entries: nil,
+				check: func(err error) bool {
+					return err == errs.ErrEmptyArchive
+				},
+			},
+		}
+		for _, tt := range tests {
+			t.Run(gen.name+" "+tt.name, func(t *testing.T) {
+				dstDir := filepath.Join(t.TempDir(), "dst")
+				assert.Nil(t, os.Mkdir(dstDir, 0755))
+				_, err := Extract(gen.write(t, tt.entries), dstDir)
+				assert.True(t, tt.check(err), "unexpected error: %v", err)
+				_, err = os.Lstat(filepath.Join(filepath.Dir(dstDir), "evil"))
+				assert.True(t, os.IsNotExist(err))
+				_, err = os.Lstat(filepath.Join(dstDir, "evil"))
+				assert.True(t, os.IsNotExist(err))
+			})
+		}
+	}
+
+	t.Run("超出最大解压大小", func(t *testing.T) {
+		filename := writeTarGz(t, []testEntry{
+			{name: "go/a", body: "0123456789", mode: 0644, typeflag: tar.TypeReg},
+			{name: "go/b", body: "0123456789", mode: 0644, typeflag: tar.TypeReg},
+		})
+		_, err := NewExtractor(WithMaxSize(15)).Extract(filename, t.TempDir())
+		assert.Equal(t, errs.ErrArchiveTooLarge, err)
+	})
+
+	t.Run("不支持的压缩包格式", func(t *testing.T) {
+		_, err := Extract("go1.21.4.linux-amd64.rar", t.TempDir())
+		assert.Equal(t, errs.ErrUnsupportedArchiveFormat, err)
+	})
+
+	t.Run("损坏的压缩包", func(t *testing.T) {
+		filename := filepath.Join(t.TempDir(), "go.tar.gz")
+		assert.Nil(t, os.WriteFile(filename, []byte("not a gzip stream"), 0644))
+		_, err := Extract(filename, t.TempDir())
+		assert.True(t, errs.IsMalformedArchive(err))
+	})
+}
+
+func TestUncompressedSize(t *testing.T) {
+	entries := []testEntry{
+		{name: "go/", mode: 0755, typeflag: tar.TypeDir},
+		{name: "go/VERSION", body: "go1.21.4", mode: 0644, typeflag: tar.TypeReg},
+		{name: "go/bin/go", body: "#!/bin/sh", mode: 0755, typeflag: tar.TypeReg},
+	}
+
+	t.Run("tar.gz", func(t *testing.T) {
+		size, err := UncompressedSize(writeTarGz(t, entries))
+		assert.Nil(t, err)
+		assert.Equal(t, uint64(3*512+2*512+2*512), size) // three headers, two padded bodies and two end-of-archive blocks
+	})
+
+	t.Run("zip", func(t *testing.T) {
+		size, err := UncompressedSize(writeZip(t, entries))
+		assert.Nil(t, err)
+		assert.Equal(t, uint64(len("go1.21.4")+len("#!/bin/sh")), size)
+	})
+
+	t.Run("不支持的压缩包格式", func(t *testing.T) {
+		_, err := UncompressedSize("go1.21.4.linux-amd64.rar")
+		assert.Equal(t, errs.ErrUnsupportedArchiveFormat, err)
+	})
+}
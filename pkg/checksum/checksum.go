@@ -22,12 +22,16 @@ package checksum
 import (
 	"crypto/sha1"
 	"crypto/sha256"
+	"crypto/sha512"
 	"encoding/hex"
 	"hash"
 	"io"
 	"os"
+	"strings"
 
 	"github.com/voidint/g/pkg/errs"
+	"golang.org/x/crypto/blake2b"
+	"lukechampine.com/blake3"
 )
 
 // Algorithm checksum algorithm type
@@ -38,32 +42,104 @@ const (
 	SHA256 Algorithm = "SHA256"
 	// SHA1 algorithm using SHA-1 hash function.
 	SHA1 Algorithm = "SHA1"
+	// SHA512 algorithm using SHA-512 hash function.
+	SHA512 Algorithm = "SHA512"
+	// BLAKE2b algorithm using BLAKE2b-512 hash function (as b2sum does).
+	BLAKE2b Algorithm = "BLAKE2b"
+	// BLAKE3 algorithm using BLAKE3 hash function with 256-bit output.
+	BLAKE3 Algorithm = "BLAKE3"
 )
 
-// VerifyFile validates file integrity against expected checksum.
-func VerifyFile(algo Algorithm, expectedChecksum, filename string) (err error) {
-	f, err := os.Open(filename)
+// ParseAlgorithm returns the algorithm matching the name case-insensitively (e.g. 'sha256', 'SHA-256', 'blake2b-512').
+func ParseAlgorithm(name string) (Algorithm, error) {
+	switch strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(name), "-", "")) {
+	case "SHA1":
+		return SHA1, nil
+	case "SHA256":
+		return SHA256, nil
+	case "SHA512":
+		return SHA512, nil
+	case "BLAKE2B", "BLAKE2B512", "B2":
+		return BLAKE2b, nil
+	case "BLAKE3", "B3":
+		return BLAKE3, nil
+	default:
+		return "", errs.ErrUnsupportedChecksumAlgorithm
+	}
+}
+
+// DetectAlgorithm infers the algorithm from the length of the hex encoded digest.
+// Digests of the same length are attributed to the SHA family, i.e. 64 characters mean SHA256 rather than BLAKE3.
+func DetectAlgorithm(digest string) (Algorithm, error) {
+	if !isHex(digest) {
+		return "", errs.ErrUnsupportedChecksumAlgorithm
+	}
+	switch len(digest) {
+	case sha1.Size * 2:
+		return SHA1, nil
+	case sha256.Size * 2:
+		return SHA256, nil
+	case sha512.Size * 2:
+		return SHA512, nil
+	default:
+		return "", errs.ErrUnsupportedChecksumAlgorithm
+	}
+}
+
+// Size returns the length in bytes of the digest produced by the algorithm.
+func (algo Algorithm) Size() int {
+	h, err := algo.New()
 	if err != nil {
-		return err
+		return 0
 	}
-	defer f.Close()
+	return h.Size()
+}
 
-	var h hash.Hash
+// New returns a new hash computing the checksum with the algorithm.
+func (algo Algorithm) New() (hash.Hash, error) {
 	switch algo {
 	case SHA256:
-		h = sha256.New()
+		return sha256.New(), nil
 	case SHA1:
-		h = sha1.New()
+		return sha1.New(), nil
+	case SHA512:
+		return sha512.New(), nil
+	case BLAKE2b:
+		return blake2b.New512(nil)
+	case BLAKE3:
+		return blake3.New(32, nil), nil
 	default:
-		return errs.ErrUnsupportedChecksumAlgorithm
+		return nil, errs.ErrUnsupportedChecksumAlgorithm
 	}
+}
+
+// VerifyFile validates file integrity against expected checksum.
+func VerifyFile(algo Algorithm, expectedChecksum, filename string) (err error) {
+	h, err := algo.New()
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
 
 	if _, err = io.Copy(h, f); err != nil {
 		return err
 	}
 
-	if expectedChecksum != hex.EncodeToString(h.Sum(nil)) {
+	if !strings.EqualFold(expectedChecksum, hex.EncodeToString(h.Sum(nil))) {
 		return errs.ErrChecksumNotMatched
 	}
 	return nil
 }
+
+func isHex(s string) bool {
+	if s == "" || len(s)%2 != 0 {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
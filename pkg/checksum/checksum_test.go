@@ -55,6 +55,42 @@ func TestVerifyFile(t *testing.T) {
 			},
 			err: nil,
 		},
+		{
+			name: "SHA512",
+			args: args{
+				algo:             SHA512,
+				expectedChecksum: "dfa6b727753e96a9dffb10947f99b8457ef51a634ea8baa0db0f22712f0bb19ac6719d4446109dc19dfc9629a3b225c0aeeebd61175464a95e35c437cd979a64",
+				filename:         "./testdata/hello.txt",
+			},
+			err: nil,
+		},
+		{
+			name: "BLAKE2b",
+			args: args{
+				algo:             BLAKE2b,
+				expectedChecksum: "f1a8a309fd307731378997291ebe101c24944b1273cc3f6a97087bd91bb33553b6c681e2ec5d3175486e00f034ed4961cb5ddee2214edadf01e35703b9eb1c87",
+				filename:         "./testdata/hello.txt",
+			},
+			err: nil,
+		},
+		{
+			name: "BLAKE3",
+			args: args{
+				algo:             BLAKE3,
+				expectedChecksum: "22b8d362b2e8064356915b1451f630d1d920b427d3b2f9b3432fbf4c03d94184",
+				filename:         "./testdata/hello.txt",
+			},
+			err: nil,
+		},
+		{
+			name: "upper case checksum",
+			args: args{
+				algo:             SHA1,
+				expectedChecksum: "8233F28C479FF758B3B4BA9AD66069DB68811E59",
+				filename:         "./testdata/hello.txt",
+			},
+			err: nil,
+		},
 		{
 			name: "unsupported checksum algorithm",
 			args: args{
@@ -80,3 +116,60 @@ func TestVerifyFile(t *testing.T) {
 		})
 	}
 }
+
+func TestParseAlgorithm(t *testing.T) {
+	tests := []struct {
+		name string
+		want Algorithm
+		err  error
+	}{
+		{name: "sha1", want: SHA1},
+		{name: "SHA256", want: SHA256},
+		{name: "SHA-256", want: SHA256},
+		{name: " sha512 ", want: SHA512},
+		{name: "BLAKE2b", want: BLAKE2b},
+		{name: "blake2b-512", want: BLAKE2b},
+		{name: "B3", want: BLAKE3},
+		{name: "md5", err: errs.ErrUnsupportedChecksumAlgorithm},
+		{name: "", err: errs.ErrUnsupportedChecksumAlgorithm},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAlgorithm(tt.name)
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDetectAlgorithm(t *testing.T) {
+	tests := []struct {
+		name   string
+		digest string
+		want   Algorithm
+		err    error
+	}{
+		{name: "SHA1", digest: "8233f28c479ff758b3b4ba9ad66069db68811e59", want: SHA1},
+		{name: "SHA256", digest: "a5f4396b45548597f81681147f53c66065d5137f2fbd85e6758a8983107228e4", want: SHA256},
+		{name: "SHA512", digest: "dfa6b727753e96a9dffb10947f99b8457ef51a634ea8baa0db0f22712f0bb19ac6719d4446109dc19dfc9629a3b225c0aeeebd61175464a95e35c437cd979a64", want: SHA512},
+		{name: "not hex", digest: "zz", err: errs.ErrUnsupportedChecksumAlgorithm},
+		{name: "unknown length", digest: "abcd", err: errs.ErrUnsupportedChecksumAlgorithm},
+		{name: "empty", digest: "", err: errs.ErrUnsupportedChecksumAlgorithm},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DetectAlgorithm(tt.digest)
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAlgorithm_Size(t *testing.T) {
+	assert.Equal(t, 20, SHA1.Size())
+	assert.Equal(t, 32, SHA256.Size())
+	assert.Equal(t, 64, SHA512.Size())
+	assert.Equal(t, 64, BLAKE2b.Size())
+	assert.Equal(t, 32, BLAKE3.Size())
+	assert.Equal(t, 0, Algorithm("MD5").Size())
+}
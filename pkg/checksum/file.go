@@ -0,0 +1,118 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package checksum
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/voidint/g/pkg/errs"
+)
+
+// Entry is a single record of a checksum file.
+type Entry struct {
+	// Algorithm is only known when the record is tagged (BSD style), otherwise it is empty.
+	Algorithm Algorithm
+	Digest    string
+	// FileName is empty for bare digests.
+	FileName string
+}
+
+// ParseFile parses the checksum file content and returns all of its records.
+//
+//	Supported formats:
+//	1. Bare hex digest (e.g. 'a5f4396b...')
+//	2. GNU coreutils style (e.g. 'a5f4396b...  go1.21.4.linux-amd64.tar.gz' or 'a5f4396b... *go1.21.4.linux-amd64.tar.gz')
+//	3. BSD tagged style (e.g. 'SHA256 (go1.21.4.linux-amd64.tar.gz) = a5f4396b...')
+//	4. Aggregate files listing many archives in the above styles (e.g. 'SHA256SUMS')
+//
+// Blank lines, comments and the armor lines of clear-signed files are ignored.
+func ParseFile(data []byte) (entries []Entry) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if e, ok := parseLine(line); ok {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// Lookup returns the record for the specified file in the checksum file content.
+// A bare digest is taken to be the checksum of whatever file it accompanies.
+func Lookup(data []byte, filename string) (Entry, error) {
+	base := path.Base(strings.ReplaceAll(filename, `\`, "/"))
+	for _, e := range ParseFile(data) {
+		if e.FileName == "" || path.Base(e.FileName) == base {
+			return e, nil
+		}
+	}
+	return Entry{}, errs.ErrChecksumEntryNotFound
+}
+
+// WriteFile writes the digests keyed by file name in the GNU coreutils style (the format of 'sha256sum'), sorted by file name.
+func WriteFile(w io.Writer, digests map[string]string) (err error) {
+	names := make([]string, 0, len(digests))
+	for name := range digests {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	for _, name := range names {
+		_, _ = fmt.Fprintf(&buf, "%s  %s\n", strings.ToLower(digests[name]), name)
+	}
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+func parseLine(line string) (e Entry, ok bool) {
+	// BSD tagged style: ALGO (filename) = digest
+	if open := strings.Index(line, " ("); open > 0 {
+		if closing := strings.LastIndex(line, ") = "); closing > open {
+			algo, err := ParseAlgorithm(line[:open])
+			digest := strings.TrimSpace(line[closing+len(") = "):])
+			if err != nil || !isHex(digest) {
+				return e, false
+			}
+			return Entry{Algorithm: algo, Digest: strings.ToLower(digest), FileName: line[open+len(" (") : closing]}, true
+		}
+	}
+
+	fields := strings.Fields(line)
+	// GNU coreutils escapes names containing special characters with a leading backslash.
+	digest := strings.TrimPrefix(fields[0], `\`)
+	if !isHex(digest) {
+		return e, false
+	}
+	e.Digest = strings.ToLower(digest)
+	if len(fields) > 1 {
+		e.FileName = strings.TrimPrefix(strings.TrimSpace(line[len(fields[0]):]), "*")
+	}
+	return e, true
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package checksum
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/voidint/g/pkg/errs"
+)
+
+const sha256sums = `# Checksums of go1.21.4
+-----BEGIN PGP SIGNED MESSAGE-----
+Hash: SHA256
+
+7c8b2e4d7c1e2e6a4b58c9a0ac8a5d4ef1c1d10e86f45a5f1f4d80a0cbe0e3f3  go1.21.4.darwin-amd64.tar.gz
+8b7caf2ac60bdff457dba7d4ff2a01def889592b834453431ae3caecf884f6a5 *go1.21.4.darwin-arm64.tar.gz
+SHA256 (go1.21.4.linux-amd64.tar.gz) = 73CAC0215254D0C7D1241FA40837851F3B9A8A742D0B54714CBDFB3FEAF8F0AF
+\7c8b2e4d7c1e2e6a4b58c9a0ac8a5d4ef1c1d10e86f45a5f1f4d80a0cbe0e3f3  dist/go1.21.4\\nlinux-arm64.tar.gz
+not a checksum line
+`
+
+func TestParseFile(t *testing.T) {
+	t.Run("裸摘要", func(t *testing.T) {
+		entries := ParseFile([]byte("a5f4396b45548597f81681147f53c66065d5137f2fbd85e6758a8983107228e4\n"))
+		assert.Equal(t, []Entry{{Digest: "a5f4396b45548597f81681147f53c66065d5137f2fbd85e6758a8983107228e4"}}, entries)
+	})
+
+	t.Run("聚合校验和文件", func(t *testing.T) {
+		entries := ParseFile([]byte(sha256sums))
+		assert.Equal(t, 4, len(entries))
+		assert.Equal(t, Entry{
+			Digest:   "7c8b2e4d7c1e2e6a4b58c9a0ac8a5d4ef1c1d10e86f45a5f1f4d80a0cbe0e3f3",
+			FileName: "go1.21.4.darwin-amd64.tar.gz",
+		}, entries[0])
+		assert.Equal(t, "go1.21.4.darwin-arm64.tar.gz", entries[1].FileName)
+		assert.Equal(t, Entry{
+			Algorithm: SHA256,
+			Digest:    "73cac0215254d0c7d1241fa40837851f3b9a8a742d0b54714cbdfb3feaf8f0af",
+			FileName:  "go1.21.4.linux-amd64.tar.gz",
+		}, entries[2])
+		assert.Equal(t, "7c8b2e4d7c1e2e6a4b58c9a0ac8a5d4ef1c1d10e86f45a5f1f4d80a0cbe0e3f3", entries[3].Digest)
+	})
+
+	t.Run("不支持的算法标签", func(t *testing.T) {
+		assert.Equal(t, 0, len(ParseFile([]byte("MD5 (go1.21.4.src.tar.gz) = 5d41402abc4b2a76b9719d911017c592\n"))))
+	})
+}
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		filename string
+		want     Entry
+		err      error
+	}{
+		{
+			name:     "裸摘要匹配任意文件",
+			data:     "8233f28c479ff758b3b4ba9ad66069db68811e59",
+			filename: "go1.21.4.linux-amd64.tar.gz",
+			want:     Entry{Digest: "8233f28c479ff758b3b4ba9ad66069db68811e59"},
+		},
+		{
+			name:     "按文件名查找",
+			data:     sha256sums,
+			filename: "/tmp/downloads/go1.21.4.darwin-arm64.tar.gz",
+			want: Entry{
+				Digest:   "8b7caf2ac60bdff457dba7d4ff2a01def889592b834453431ae3caecf884f6a5",
+				FileName: "go1.21.4.darwin-arm64.tar.gz",
+			},
+		},
+		{
+			name:     "BSD风格",
+			data:     sha256sums,
+			filename: "go1.21.4.linux-amd64.tar.gz",
+			want: Entry{
+				Algorithm: SHA256,
+				Digest:    "73cac0215254d0c7d1241fa40837851f3b9a8a742d0b54714cbdfb3feaf8f0af",
+				FileName:  "go1.21.4.linux-amd64.tar.gz",
+			},
+		},
+		{
+			name:     "未找到记录",
+			data:     sha256sums,
+			filename: "go1.21.4.windows-amd64.zip",
+			err:      errs.ErrChecksumEntryNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Lookup([]byte(tt.data), tt.filename)
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestWriteFile(t *testing.T) {
+	var buf bytes.Buffer
+	assert.Nil(t, WriteFile(&buf, map[string]string{
+		"go1.21.4.linux-amd64.tar.gz":  "73CAC0215254D0C7D1241FA40837851F3B9A8A742D0B54714CBDFB3FEAF8F0AF",
+		"go1.21.4.darwin-arm64.tar.gz": "8b7caf2ac60bdff457dba7d4ff2a01def889592b834453431ae3caecf884f6a5",
+	}))
+	assert.Equal(t, "8b7caf2ac60bdff457dba7d4ff2a01def889592b834453431ae3caecf884f6a5  go1.21.4.darwin-arm64.tar.gz\n"+
+		"73cac0215254d0c7d1241fa40837851f3b9a8a742d0b54714cbdfb3feaf8f0af  go1.21.4.linux-amd64.tar.gz\n", buf.String())
+}
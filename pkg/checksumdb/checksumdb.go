@@ -0,0 +1,126 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// Package checksumdb provides the official SHA-256 digests of the Go release packages.
+//
+// The embedded database is regenerated from the official download page before each release of g with
+//
+//	make update-checksums
+package checksumdb
+
+import (
+	_ "embed"
+	"os"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/voidint/g/pkg/checksum"
+	"github.com/voidint/g/pkg/errs"
+)
+
+//go:embed SHA256SUMS
+var embedded []byte
+
+// DB maps the package file names to their official SHA-256 digests.
+type DB struct {
+	digests map[string]string
+}
+
+// New creates a database with the embedded digests and the digests in the files.
+// Files that do not exist are ignored, and later files take precedence over earlier ones.
+// The files may add digests but never replace an embedded digest with a different one.
+func New(files ...string) (*DB, error) {
+	official := parse(embedded)
+	db := DB{digests: make(map[string]string, len(official))}
+	for name, digest := range official {
+		db.digests[name] = digest
+	}
+
+	for _, filename := range files {
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, errors.WithStack(err)
+		}
+		for name, digest := range parse(data) {
+			if d, ok := official[name]; ok && d != digest {
+				return nil, errors.Wrapf(errs.ErrChecksumConflict, "%q: %s", filename, name)
+			}
+			db.digests[name] = digest
+		}
+	}
+	return &db, nil
+}
+
+// parse returns the SHA-256 digests in the checksum file keyed by file name.
+func parse(data []byte) map[string]string {
+	digests := make(map[string]string, 2048)
+	for _, e := range checksum.ParseFile(data) {
+		if e.FileName == "" || len(e.Digest) != checksum.SHA256.Size()*2 {
+			continue
+		}
+		if e.Algorithm != "" && e.Algorithm != checksum.SHA256 {
+			continue
+		}
+		digests[path.Base(e.FileName)] = e.Digest
+	}
+	return digests
+}
+
+// Lookup returns the official SHA-256 digest of the package file.
+func (db *DB) Lookup(filename string) (digest string, ok bool) {
+	if db == nil {
+		return "", false
+	}
+	digest, ok = db.digests[path.Base(strings.ReplaceAll(filename, `\`, "/"))]
+	return digest, ok
+}
+
+// Len returns the number of the digests in the database.
+func (db *DB) Len() int {
+	if db == nil {
+		return 0
+	}
+	return len(db.digests)
+}
+
+var (
+	defaultDB   *DB
+	defaultOnce sync.Once
+)
+
+// Default returns the database in use, which contains the embedded digests unless SetDefault has been called.
+func Default() *DB {
+	defaultOnce.Do(func() {
+		if defaultDB == nil {
+			defaultDB, _ = New()
+		}
+	})
+	return defaultDB
+}
+
+// SetDefault replaces the database in use.
+func SetDefault(db *DB) {
+	defaultOnce.Do(func() {})
+	defaultDB = db
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package checksumdb
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/voidint/g/pkg/errs"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("内嵌的校验和", func(t *testing.T) {
+		db, err := New()
+		assert.Nil(t, err)
+		assert.True(t, db.Len() > 4000)
+
+		digest, ok := db.Lookup("go1.21.4.linux-amd64.tar.gz")
+		assert.True(t, ok)
+		assert.Equal(t, "73cac0215254d0c7d1241fa40837851f3b9a8a742d0b54714cbdfb3feaf8f0af", digest)
+
+		digest, ok = db.Lookup(filepath.Join("downloads", "go1.21.4.darwin-arm64.tar.gz"))
+		assert.True(t, ok)
+		assert.Equal(t, "8b7caf2ac60bdff457dba7d4ff2a01def889592b834453431ae3caecf884f6a5", digest)
+
+		_, ok = db.Lookup("go0.0.1.linux-amd64.tar.gz")
+		assert.False(t, ok)
+	})
+
+	t.Run("更新后的校验和", func(t *testing.T) {
+		dir := t.TempDir()
+		older := filepath.Join(dir, "SHA256SUMS.old")
+		assert.Nil(t, os.WriteFile(older, []byte(
+			"2222222222222222222222222222222222222222222222222222222222222222  go1.99.0.linux-arm64.tar.gz\n",
+		), 0644))
+		filename := filepath.Join(dir, "SHA256SUMS")
+		assert.Nil(t, os.WriteFile(filename, []byte(
+			"SHA256 (go1.21.4.linux-amd64.tar.gz) = 73CAC0215254D0C7D1241FA40837851F3B9A8A742D0B54714CBDFB3FEAF8F0AF\n"+
+				"8233f28c479ff758b3b4ba9ad66069db68811e59  go1.99.0.linux-amd64.tar.gz\n"+
+				"1111111111111111111111111111111111111111111111111111111111111111  go1.99.0.linux-arm64.tar.gz\n",
+		), 0644))
+
+		db, err := New(filepath.Join(dir, "404"), older, filename)
+		assert.Nil(t, err)
+
+		digest, ok := db.Lookup("go1.21.4.linux-amd64.tar.gz")
+		assert.True(t, ok)
+		assert.Equal(t, "73cac0215254d0c7d1241fa40837851f3b9a8a742d0b54714cbdfb3feaf8f0af", digest)
+
+		_, ok = db.Lookup("go1.99.0.linux-amd64.tar.gz") // SHA1
+		assert.False(t, ok)
+
+		digest, ok = db.Lookup("go1.99.0.linux-arm64.tar.gz") // later files take precedence
+		assert.True(t, ok)
+		assert.Equal(t, "1111111111111111111111111111111111111111111111111111111111111111", digest)
+	})
+
+	t.Run("与内嵌的官方校验和冲突", func(t *testing.T) {
+		filename := filepath.Join(t.TempDir(), "SHA256SUMS")
+		assert.Nil(t, os.WriteFile(filename, []byte(
+			"0000000000000000000000000000000000000000000000000000000000000000  go1.21.4.linux-amd64.tar.gz\n",
+		), 0644))
+
+		_, err := New(filename)
+		assert.ErrorIs(t, err, errs.ErrChecksumConflict)
+		assert.Contains(t, err.Error(), "go1.21.4.linux-amd64.tar.gz")
+	})
+
+	t.Run("读取文件失败", func(t *testing.T) {
+		_, err := New(t.TempDir())
+		assert.NotNil(t, err)
+	})
+}
+
+func TestDefault(t *testing.T) {
+	defer SetDefault(Default())
+
+	assert.True(t, Default().Len() > 0)
+
+	SetDefault(nil)
+	assert.Equal(t, 0, Default().Len())
+	_, ok := Default().Lookup("go1.21.4.linux-amd64.tar.gz")
+	assert.False(t, ok)
+}
@@ -32,19 +32,59 @@ var (
 	ErrChecksumNotMatched = errors.New("file checksum does not match the computed checksum")
 	// ErrChecksumFileNotFound Checksum file not found
 	ErrChecksumFileNotFound = errors.New("checksum file not found")
+	// ErrChecksumEntryNotFound Checksum file has no record for the file
+	ErrChecksumEntryNotFound = errors.New("checksum entry not found")
+	// ErrChecksumConflict Checksum file contradicts the embedded official checksum
+	ErrChecksumConflict = errors.New("checksum conflicts with the embedded official checksum")
 	// ErrAssetNotFound Asset not found
 	ErrAssetNotFound = errors.New("asset not found")
 	// ErrCollectorNotFound Collector not found
 	ErrCollectorNotFound = errors.New("collector not found")
 	// ErrEmptyURL URL is empty
 	ErrEmptyURL = errors.New("empty url")
+	// ErrUnsupportedArchiveFormat Unsupported archive format
+	ErrUnsupportedArchiveFormat = errors.New("unsupported archive format")
+	// ErrEmptyArchive Archive contains no entries
+	ErrEmptyArchive = errors.New("archive is empty")
+	// ErrArchiveTooLarge Archive exceeds the maximum uncompressed size
+	ErrArchiveTooLarge = errors.New("archive exceeds the maximum uncompressed size")
+	// ErrUnsupportedPlatform Operation is not supported on the current platform
+	ErrUnsupportedPlatform = errors.New("unsupported platform")
+	// ErrSignatureNotMatched Signature does not match the file
+	ErrSignatureNotMatched = errors.New("file signature verification failed")
+	// ErrUnknownSigner Signature was made by a key that is not in the keyring
+	ErrUnknownSigner = errors.New("file is signed by an unknown key")
+	// ErrSignerInvalid Signature was made by a key that was expired or revoked at the time
+	ErrSignerInvalid = errors.New("file is signed by a key that was expired or revoked when signing")
+	// ErrSignatureNotFound Signature file not found
+	ErrSignatureNotFound = errors.New("signature file not found")
+	// ErrManifestNotFound File hash manifest of the installed version not found
+	ErrManifestNotFound = errors.New("manifest not found, the version was installed by an older g")
+	// ErrSumDBDisabled Checksum database is disabled by GOSUMDB=off
+	ErrSumDBDisabled = errors.New("checksum database is disabled (GOSUMDB=off)")
+	// ErrSumDBMismatch Module zip does not match the checksum database
+	ErrSumDBMismatch = errors.New("module zip does not match the checksum database")
+	// ErrNoModuleProxy No module proxy in GOPROXY
+	ErrNoModuleProxy = errors.New("no module proxy in GOPROXY")
+	// ErrModuleProxyDisabled Module downloads are disabled by GOPROXY=off
+	ErrModuleProxyDisabled = errors.New("module downloads are disabled (GOPROXY=off)")
+	// ErrProjectFileNotFound No project file selecting the Go version was found
+	ErrProjectFileNotFound = errors.New("no .go-version, .tool-versions, go.work or go.mod file found")
+	// ErrGoDirectiveNotFound Project file names no Go version
+	ErrGoDirectiveNotFound = errors.New("go version not found")
+	// ErrUnsafePackageName Package file name supplied by the mirror is not a plain file name
+	ErrUnsafePackageName = errors.New("package file name is not a plain file name")
+	// ErrLocked Lock is held by another process
+	ErrLocked = errors.New("another g process is running, please try again later")
 )
 
 // PackageNotFoundError indicates the requested package does not exist.
 type PackageNotFoundError struct {
-	kind   string
-	goos   string
-	goarch string
+	kind      string
+	goos      string
+	goarch    string
+	version   string
+	platforms []string
 }
 
 // IsPackageNotFound checks if the error indicates missing package.
@@ -56,25 +96,61 @@ func IsPackageNotFound(err error) bool {
 	return ok
 }
 
+// WithPackageVersion sets the version lacking the package.
+func WithPackageVersion(version string) func(e *PackageNotFoundError) {
+	return func(e *PackageNotFoundError) {
+		e.version = version
+	}
+}
+
+// WithPlatforms sets the platforms (e.g. 'linux/amd64') the version does have a package for.
+func WithPlatforms(platforms ...string) func(e *PackageNotFoundError) {
+	return func(e *PackageNotFoundError) {
+		e.platforms = platforms
+	}
+}
+
 // NewPackageNotFoundError creates a package missing error instance.
-func NewPackageNotFoundError(kind, goos, goarch string) error {
-	return &PackageNotFoundError{
+func NewPackageNotFoundError(kind, goos, goarch string, opts ...func(e *PackageNotFoundError)) error {
+	e := PackageNotFoundError{
 		kind:   kind,
 		goos:   goos,
 		goarch: goarch,
 	}
+	for _, setter := range opts {
+		setter(&e)
+	}
+	return &e
 }
 
 // Error returns detailed error message.
 func (e PackageNotFoundError) Error() string {
-	return fmt.Sprintf("package not found [%s,%s,%s]", e.goos, e.goarch, e.kind)
+	msg := fmt.Sprintf("package not found [%s,%s,%s]", e.goos, e.goarch, e.kind)
+	switch {
+	case len(e.platforms) > 0 && e.version != "":
+		msg += fmt.Sprintf(", %s is available for %s", e.version, strings.Join(e.platforms, ", "))
+	case len(e.platforms) > 0:
+		msg += ", available for " + strings.Join(e.platforms, ", ")
+	}
+	return msg
+}
+
+// Version returns the version lacking the package, if known.
+func (e PackageNotFoundError) Version() string {
+	return e.version
+}
+
+// Platforms returns the platforms (e.g. 'linux/amd64') the version does have a package for.
+func (e PackageNotFoundError) Platforms() []string {
+	return e.platforms
 }
 
 // VersionNotFoundError indicates the specified version is unavailable.
 type VersionNotFoundError struct {
-	version string
-	goos    string
-	goarch  string
+	version     string
+	goos        string
+	goarch      string
+	suggestions []string
 }
 
 // IsVersionNotFound checks if the error indicates missing version.
@@ -86,18 +162,33 @@ func IsVersionNotFound(err error) bool {
 	return ok
 }
 
+// WithSuggestions sets the available versions close to the requested one.
+func WithSuggestions(versions ...string) func(e *VersionNotFoundError) {
+	return func(e *VersionNotFoundError) {
+		e.suggestions = versions
+	}
+}
+
 // NewVersionNotFoundError creates a version missing error instance.
-func NewVersionNotFoundError(version, goos, goarch string) error {
-	return &VersionNotFoundError{
+func NewVersionNotFoundError(version, goos, goarch string, opts ...func(e *VersionNotFoundError)) error {
+	e := VersionNotFoundError{
 		version: version,
 		goos:    goos,
 		goarch:  goarch,
 	}
+	for _, setter := range opts {
+		setter(&e)
+	}
+	return &e
 }
 
 // Error returns detailed error message.
 func (e VersionNotFoundError) Error() string {
-	return fmt.Sprintf("version not found %q [%s,%s]", e.version, e.goos, e.goarch)
+	msg := fmt.Sprintf("version not found %q [%s,%s]", e.version, e.goos, e.goarch)
+	if len(e.suggestions) > 0 {
+		msg += ", did you mean " + strings.Join(e.suggestions, ", ") + "?"
+	}
+	return msg
 }
 
 // Version returns the semantic version string.
@@ -105,6 +196,11 @@ func (e VersionNotFoundError) Version() string {
 	return e.version
 }
 
+// Suggestions returns the available versions close to the requested one.
+func (e VersionNotFoundError) Suggestions() []string {
+	return e.suggestions
+}
+
 // MalformedVersionError indicates invalid version format.
 type MalformedVersionError struct {
 	err     error
@@ -228,3 +324,251 @@ func (e DownloadError) Unwrap() error {
 func (e DownloadError) URL() string {
 	return e.url
 }
+
+// MalformedArchiveError indicates a malicious or malformed archive entry.
+type MalformedArchiveError struct {
+	entry  string
+	reason string
+}
+
+// IsMalformedArchive checks if the error indicates a rejected archive entry.
+func IsMalformedArchive(err error) bool {
+	if err == nil {
+		return false
+	}
+	_, ok := err.(*MalformedArchiveError)
+	return ok
+}
+
+// NewMalformedArchiveError creates a malformed archive error instance.
+func NewMalformedArchiveError(entry, reason string) error {
+	return &MalformedArchiveError{
+		entry:  entry,
+		reason: reason,
+	}
+}
+
+// Error returns detailed error message.
+func (e MalformedArchiveError) Error() string {
+	return fmt.Sprintf("malformed archive entry %q: %s", e.entry, e.reason)
+}
+
+// Entry returns the name of the rejected archive entry.
+func (e MalformedArchiveError) Entry() string {
+	return e.entry
+}
+
+// Reason returns the reason why the entry was rejected.
+func (e MalformedArchiveError) Reason() string {
+	return e.reason
+}
+
+// InsufficientDiskSpaceError indicates there is not enough free disk space for the operation.
+type InsufficientDiskSpaceError struct {
+	dir       string
+	required  uint64
+	available uint64
+}
+
+// IsInsufficientDiskSpace checks if the error indicates a lack of free disk space.
+func IsInsufficientDiskSpace(err error) bool {
+	if err == nil {
+		return false
+	}
+	_, ok := err.(*InsufficientDiskSpaceError)
+	return ok
+}
+
+// NewInsufficientDiskSpaceError creates an insufficient disk space error instance.
+func NewInsufficientDiskSpaceError(dir string, required, available uint64) error {
+	return &InsufficientDiskSpaceError{
+		dir:       dir,
+		required:  required,
+		available: available,
+	}
+}
+
+// Error returns detailed error message.
+func (e InsufficientDiskSpaceError) Error() string {
+	return fmt.Sprintf("insufficient disk space in %q: %d MB required, %d MB available", e.dir, e.required>>20, e.available>>20)
+}
+
+// Required returns the number of bytes required.
+func (e InsufficientDiskSpaceError) Required() uint64 {
+	return e.required
+}
+
+// Available returns the number of bytes available.
+func (e InsufficientDiskSpaceError) Available() uint64 {
+	return e.available
+}
+
+// InvalidKeyringError indicates the OpenPGP keyring cannot be loaded.
+type InvalidKeyringError struct {
+	file string
+	err  error
+}
+
+// IsInvalidKeyring checks if the error indicates an unusable keyring.
+func IsInvalidKeyring(err error) bool {
+	if err == nil {
+		return false
+	}
+	_, ok := err.(*InvalidKeyringError)
+	return ok
+}
+
+// NewInvalidKeyringError creates an invalid keyring error instance.
+func NewInvalidKeyringError(file string, err error) error {
+	return &InvalidKeyringError{
+		file: file,
+		err:  err,
+	}
+}
+
+// Error returns detailed error message.
+func (e InvalidKeyringError) Error() string {
+	if e.file == "" {
+		return fmt.Sprintf("invalid embedded keyring: %v", e.err)
+	}
+	return fmt.Sprintf("invalid keyring %q: %v", e.file, e.err)
+}
+
+// Unwrap returns the original error object.
+func (e InvalidKeyringError) Unwrap() error {
+	return e.err
+}
+
+// File returns the keyring file path.
+func (e InvalidKeyringError) File() string {
+	return e.file
+}
+
+// MalformedGOSUMDBError indicates the GOSUMDB setting cannot be parsed.
+type MalformedGOSUMDBError struct {
+	gosumdb string
+	err     error
+}
+
+// IsMalformedGOSUMDB checks if the error indicates an invalid GOSUMDB setting.
+func IsMalformedGOSUMDB(err error) bool {
+	if err == nil {
+		return false
+	}
+	_, ok := err.(*MalformedGOSUMDBError)
+	return ok
+}
+
+// NewMalformedGOSUMDBError creates a malformed GOSUMDB error instance.
+func NewMalformedGOSUMDBError(gosumdb string, err error) error {
+	return &MalformedGOSUMDBError{
+		gosumdb: gosumdb,
+		err:     err,
+	}
+}
+
+// Error returns detailed error message.
+func (e MalformedGOSUMDBError) Error() string {
+	if e.err == nil {
+		return fmt.Sprintf("malformed GOSUMDB %q", e.gosumdb)
+	}
+	return fmt.Sprintf("malformed GOSUMDB %q: %v", e.gosumdb, e.err)
+}
+
+// Unwrap returns the original error object.
+func (e MalformedGOSUMDBError) Unwrap() error {
+	return e.err
+}
+
+// GOSUMDB returns the GOSUMDB setting.
+func (e MalformedGOSUMDBError) GOSUMDB() string {
+	return e.gosumdb
+}
+
+// MalformedPolicyError indicates the policy file cannot be parsed.
+type MalformedPolicyError struct {
+	file string
+	err  error
+}
+
+// IsMalformedPolicy checks if the error indicates an invalid policy file.
+func IsMalformedPolicy(err error) bool {
+	if err == nil {
+		return false
+	}
+	_, ok := err.(*MalformedPolicyError)
+	return ok
+}
+
+// NewMalformedPolicyError creates a malformed policy error instance.
+func NewMalformedPolicyError(file string, err error) error {
+	return &MalformedPolicyError{
+		file: file,
+		err:  err,
+	}
+}
+
+// Error returns detailed error message.
+func (e MalformedPolicyError) Error() string {
+	return fmt.Sprintf("malformed policy file %q: %v", e.file, e.err)
+}
+
+// Unwrap returns the original error object.
+func (e MalformedPolicyError) Unwrap() error {
+	return e.err
+}
+
+// File returns the policy file path.
+func (e MalformedPolicyError) File() string {
+	return e.file
+}
+
+// PolicyViolationError indicates an operation is forbidden by a rule of the policy file.
+type PolicyViolationError struct {
+	file    string
+	rule    string
+	version string
+	reason  string
+}
+
+// IsPolicyViolation checks if the error indicates a policy violation.
+func IsPolicyViolation(err error) bool {
+	if err == nil {
+		return false
+	}
+	_, ok := err.(*PolicyViolationError)
+	return ok
+}
+
+// NewPolicyViolationError creates a policy violation error instance.
+func NewPolicyViolationError(file, rule, version, reason string) error {
+	return &PolicyViolationError{
+		file:    file,
+		rule:    rule,
+		version: version,
+		reason:  reason,
+	}
+}
+
+// Error returns detailed error message.
+func (e PolicyViolationError) Error() string {
+	if e.version == "" {
+		return fmt.Sprintf("policy violation: rule %q in %q: %s", e.rule, e.file, e.reason)
+	}
+	return fmt.Sprintf("policy violation: rule %q in %q forbids go%s: %s", e.rule, e.file, e.version, e.reason)
+}
+
+// File returns the policy file that defines the rule.
+func (e PolicyViolationError) File() string {
+	return e.file
+}
+
+// Rule returns the name of the violated rule.
+func (e PolicyViolationError) Rule() string {
+	return e.rule
+}
+
+// Version returns the version that violates the rule.
+func (e PolicyViolationError) Version() string {
+	return e.version
+}
@@ -43,6 +43,19 @@ func TestPackageNotFoundError(t *testing.T) {
 		assert.True(t, ok)
 		assert.NotNil(t, e)
 	})
+
+	t.Run("附带可用平台的软件包不存在错误", func(t *testing.T) {
+		err := NewPackageNotFoundError("Archive", "linux", "riscv64", WithPackageVersion("1.21.4"), WithPlatforms("darwin/arm64", "linux/amd64"))
+		assert.Equal(t, "package not found [linux,riscv64,Archive], 1.21.4 is available for darwin/arm64, linux/amd64", err.Error())
+
+		e, ok := err.(*PackageNotFoundError)
+		assert.True(t, ok)
+		assert.Equal(t, "1.21.4", e.Version())
+		assert.Equal(t, []string{"darwin/arm64", "linux/amd64"}, e.Platforms())
+
+		err = NewPackageNotFoundError("Archive", "linux", "riscv64", WithPlatforms("linux/amd64"))
+		assert.Equal(t, "package not found [linux,riscv64,Archive], available for linux/amd64", err.Error())
+	})
 }
 
 func TestVersionNotFoundError(t *testing.T) {
@@ -61,6 +74,16 @@ func TestVersionNotFoundError(t *testing.T) {
 		assert.True(t, ok)
 		assert.NotNil(t, e)
 		assert.Equal(t, v, e.Version())
+		assert.Nil(t, e.Suggestions())
+	})
+
+	t.Run("附带建议的版本号不存在错误", func(t *testing.T) {
+		err := NewVersionNotFoundError("1.212", "linux", "amd64", WithSuggestions("1.21.2", "1.21.4"))
+		assert.Equal(t, `version not found "1.212" [linux,amd64], did you mean 1.21.2, 1.21.4?`, err.Error())
+
+		e, ok := err.(*VersionNotFoundError)
+		assert.True(t, ok)
+		assert.Equal(t, []string{"1.21.2", "1.21.4"}, e.Suggestions())
 	})
 }
 
@@ -118,3 +141,88 @@ func TestDownloadError(t *testing.T) {
 		assert.Equal(t, fmt.Sprintf("resource(%s) download failed ==> %s", url, core.Error()), e.Error())
 	})
 }
+
+func TestMalformedArchiveError(t *testing.T) {
+	t.Run("压缩包条目非法错误", func(t *testing.T) {
+		entry := "../etc/passwd"
+		reason := "path escapes the target directory"
+
+		err := NewMalformedArchiveError(entry, reason)
+		assert.NotNil(t, err)
+		e, ok := err.(*MalformedArchiveError)
+		assert.True(t, IsMalformedArchive(err))
+		assert.False(t, IsMalformedArchive(nil))
+		assert.True(t, ok)
+		assert.NotNil(t, e)
+		assert.Equal(t, entry, e.Entry())
+		assert.Equal(t, reason, e.Reason())
+		assert.Equal(t, fmt.Sprintf("malformed archive entry %q: %s", entry, reason), e.Error())
+	})
+}
+
+func TestInsufficientDiskSpaceError(t *testing.T) {
+	t.Run("磁盘空间不足错误", func(t *testing.T) {
+		dir := "/home/voidint/.g/versions"
+
+		err := NewInsufficientDiskSpaceError(dir, 300<<20, 100<<20)
+		assert.NotNil(t, err)
+		e, ok := err.(*InsufficientDiskSpaceError)
+		assert.True(t, IsInsufficientDiskSpace(err))
+		assert.False(t, IsInsufficientDiskSpace(nil))
+		assert.True(t, ok)
+		assert.NotNil(t, e)
+		assert.Equal(t, uint64(300<<20), e.Required())
+		assert.Equal(t, uint64(100<<20), e.Available())
+		assert.Equal(t, fmt.Sprintf("insufficient disk space in %q: 300 MB required, 100 MB available", dir), e.Error())
+	})
+}
+
+func TestInvalidKeyringError(t *testing.T) {
+	t.Run("密钥环无效错误", func(t *testing.T) {
+		file := "/home/voidint/.g/keyring.asc"
+		core := errors.New("openpgp: invalid data: tag byte does not have MSB set")
+
+		err := NewInvalidKeyringError(file, core)
+		assert.NotNil(t, err)
+		e, ok := err.(*InvalidKeyringError)
+		assert.True(t, IsInvalidKeyring(err))
+		assert.False(t, IsInvalidKeyring(nil))
+		assert.True(t, ok)
+		assert.NotNil(t, e)
+		assert.Equal(t, file, e.File())
+		assert.Equal(t, core, e.Unwrap())
+		assert.Equal(t, fmt.Sprintf("invalid keyring %q: %s", file, core.Error()), e.Error())
+		assert.Equal(t, fmt.Sprintf("invalid embedded keyring: %s", core.Error()), NewInvalidKeyringError("", core).Error())
+	})
+}
+
+func TestPolicyErrors(t *testing.T) {
+	file := "/home/voidint/.g/policy.json"
+
+	t.Run("策略文件格式错误", func(t *testing.T) {
+		core := errors.New("unexpected end of JSON input")
+
+		err := NewMalformedPolicyError(file, core)
+		e, ok := err.(*MalformedPolicyError)
+		assert.True(t, ok)
+		assert.True(t, IsMalformedPolicy(err))
+		assert.False(t, IsMalformedPolicy(nil))
+		assert.Equal(t, file, e.File())
+		assert.Equal(t, core, e.Unwrap())
+		assert.Equal(t, fmt.Sprintf("malformed policy file %q: %s", file, core.Error()), e.Error())
+	})
+
+	t.Run("违反策略错误", func(t *testing.T) {
+		err := NewPolicyViolationError(file, "min_version", "1.20.14", "versions older than 1.21.0 are not allowed")
+		e, ok := err.(*PolicyViolationError)
+		assert.True(t, ok)
+		assert.True(t, IsPolicyViolation(err))
+		assert.False(t, IsPolicyViolation(nil))
+		assert.Equal(t, file, e.File())
+		assert.Equal(t, "min_version", e.Rule())
+		assert.Equal(t, "1.20.14", e.Version())
+		assert.Equal(t, fmt.Sprintf(`policy violation: rule "min_version" in %q forbids go1.20.14: versions older than 1.21.0 are not allowed`, file), e.Error())
+		assert.Equal(t, fmt.Sprintf(`policy violation: rule "allowed_mirrors" in %q: mirror "x" is not allowed`, file),
+			NewPolicyViolationError(file, "allowed_mirrors", "", `mirror "x" is not allowed`).Error())
+	})
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package flock
+
+import (
+	"os"
+	"time"
+
+	"github.com/voidint/g/pkg/errs"
+)
+
+// pollInterval is the delay between two attempts to acquire a busy lock.
+const pollInterval = 100 * time.Millisecond
+
+// Lock is an advisory, exclusive lock held on a file and shared across processes.
+type Lock struct {
+	path string
+	f    *os.File
+}
+
+// New creates a lock backed by the file at the specified path, the file is created on demand.
+func New(path string) *Lock {
+	return &Lock{path: path}
+}
+
+// Path returns the path of the lock file.
+func (l *Lock) Path() string {
+	return l.path
+}
+
+// TryLock attempts to acquire the lock without blocking and reports whether it succeeded.
+func (l *Lock) TryLock() (locked bool, err error) {
+	if l.f != nil {
+		return true, nil
+	}
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return false, err
+	}
+	if locked, err = tryLock(f); err != nil || !locked {
+		_ = f.Close()
+		return false, err
+	}
+	l.f = f
+	return true, nil
+}
+
+// LockWithTimeout acquires the lock, waiting up to timeout for other holders to release it.
+// onWait, if not nil, is called once before waiting starts.
+func (l *Lock) LockWithTimeout(timeout time.Duration, onWait func()) error {
+	deadline := time.Now().Add(timeout)
+	for waited := false; ; waited = true {
+		locked, err := l.TryLock()
+		if err != nil {
+			return err
+		}
+		if locked {
+			return nil
+		}
+		if !time.Now().Before(deadline) {
+			return errs.ErrLocked
+		}
+		if !waited && onWait != nil {
+			onWait()
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+// Unlock releases the lock.
+func (l *Lock) Unlock() error {
+	if l.f == nil {
+		return nil
+	}
+	err := unlock(l.f)
+	if e := l.f.Close(); err == nil {
+		err = e
+	}
+	l.f = nil
+	return err
+}
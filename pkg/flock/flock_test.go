@@ -0,0 +1,65 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package flock
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/voidint/g/pkg/errs"
+)
+
+func TestLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".lock")
+	l1, l2 := New(path), New(path)
+	assert.Equal(t, path, l1.Path())
+
+	t.Run("获取锁", func(t *testing.T) {
+		locked, err := l1.TryLock()
+		assert.Nil(t, err)
+		assert.True(t, locked)
+
+		locked, err = l1.TryLock() // re-entrant for the same holder
+		assert.Nil(t, err)
+		assert.True(t, locked)
+	})
+
+	t.Run("锁被占用", func(t *testing.T) {
+		locked, err := l2.TryLock()
+		assert.Nil(t, err)
+		assert.False(t, locked)
+
+		var waited bool
+		assert.Equal(t, errs.ErrLocked, l2.LockWithTimeout(200*time.Millisecond, func() { waited = true }))
+		assert.True(t, waited)
+	})
+
+	t.Run("等待锁释放", func(t *testing.T) {
+		go func() {
+			time.Sleep(200 * time.Millisecond)
+			_ = l1.Unlock()
+		}()
+		assert.Nil(t, l2.LockWithTimeout(5*time.Second, nil))
+		assert.Nil(t, l2.Unlock())
+		assert.Nil(t, l2.Unlock())
+	})
+}
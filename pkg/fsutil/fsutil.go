@@ -0,0 +1,80 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package fsutil
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/voidint/g/pkg/errs"
+)
+
+// EnsureFreeSpace returns an error if the file system holding dir has less than required bytes available.
+// Platforms on which the free space cannot be queried are assumed to have enough room.
+func EnsureFreeSpace(dir string, required uint64) error {
+	available, err := FreeSpace(dir)
+	if err == errs.ErrUnsupportedPlatform {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if available < required {
+		return errs.NewInsufficientDiskSpaceError(dir, required, available)
+	}
+	return nil
+}
+
+// SyncDir flushes the directory entry changes (e.g. a rename) to stable storage.
+func SyncDir(dir string) error {
+	if runtime.GOOS == "windows" {
+		return nil // Directories cannot be opened for flushing on Windows.
+	}
+	f, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return f.Sync()
+}
+
+// SyncTree flushes every regular file and directory under root to stable storage.
+func SyncTree(root string) error {
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		switch {
+		case d.IsDir():
+			return SyncDir(path)
+		case d.Type().IsRegular():
+			f, err := os.OpenFile(path, os.O_RDWR, 0)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			return f.Sync()
+		default:
+			return nil
+		}
+	})
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package fsutil
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/voidint/g/pkg/errs"
+)
+
+func TestEnsureFreeSpace(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("磁盘空间充足", func(t *testing.T) {
+		assert.Nil(t, EnsureFreeSpace(dir, 1))
+	})
+
+	t.Run("磁盘空间不足", func(t *testing.T) {
+		if _, err := FreeSpace(dir); err == errs.ErrUnsupportedPlatform {
+			t.Skip(err)
+		}
+		assert.True(t, errs.IsInsufficientDiskSpace(EnsureFreeSpace(dir, math.MaxUint64)))
+	})
+}
+
+func TestSyncTree(t *testing.T) {
+	root := t.TempDir()
+	assert.Nil(t, os.MkdirAll(filepath.Join(root, "go", "bin"), 0755))
+	assert.Nil(t, os.WriteFile(filepath.Join(root, "go", "bin", "go"), []byte("#!/bin/sh"), 0755))
+	assert.Nil(t, os.Symlink("bin/go", filepath.Join(root, "go", "link")))
+
+	assert.Nil(t, SyncTree(root))
+	assert.NotNil(t, SyncTree(filepath.Join(root, "not_exist")))
+}
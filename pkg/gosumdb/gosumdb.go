@@ -0,0 +1,271 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// Package gosumdb authenticates module zips, such as the golang.org/toolchain modules, against a Go checksum database.
+package gosumdb
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/voidint/g/pkg/errs"
+	httppkg "github.com/voidint/g/pkg/http"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/sumdb"
+	"golang.org/x/mod/sumdb/dirhash"
+	"golang.org/x/mod/sumdb/note"
+)
+
+const (
+	// DefaultGOSUMDB is the checksum database used when GOSUMDB is not set.
+	DefaultGOSUMDB = "sum.golang.org"
+	// DefaultGOPROXY is the module proxy used when GOPROXY is not set.
+	DefaultGOPROXY = "https://proxy.golang.org"
+	// ToolchainModulePath is the module path of the Go toolchains distributed as modules.
+	ToolchainModulePath = "golang.org/toolchain"
+)
+
+// knownGOSUMDB maps the well-known checksum databases to their verifier keys.
+var knownGOSUMDB = map[string]string{
+	"sum.golang.org": "sum.golang.org+033de0ae+Ac4zctda0e5eza+HJyk9SxEdh+s3Ux18htTTAD8OuAn8",
+}
+
+// ToolchainVersion returns the module version of the Go toolchain for the platform, e.g. 'v0.0.1-go1.21.4.linux-amd64'.
+func ToolchainVersion(goVersion, goos, goarch string) string {
+	return fmt.Sprintf("v0.0.1-go%s.%s-%s", goVersion, goos, goarch)
+}
+
+// Proxy returns the first module proxy in the GOPROXY list. 'direct' is skipped, and like the go command,
+// 'off' disallows downloading from that point of the list on.
+func Proxy(goproxy string) (string, error) {
+	if goproxy == "" {
+		return DefaultGOPROXY, nil
+	}
+	for _, proxy := range strings.FieldsFunc(goproxy, func(r rune) bool { return r == ',' || r == '|' }) {
+		switch proxy = strings.TrimSpace(proxy); proxy {
+		case "", "direct":
+			continue
+		case "off":
+			return "", errs.ErrModuleProxyDisabled
+		}
+		return strings.TrimSuffix(proxy, "/"), nil
+	}
+	return "", errs.ErrNoModuleProxy
+}
+
+// ZipURL returns the URL of the module zip on the module proxy.
+func ZipURL(proxy, path, version string) (string, error) {
+	escPath, err := module.EscapePath(path)
+	if err != nil {
+		return "", err
+	}
+	escVersion, err := module.EscapeVersion(version)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/%s/@v/%s.zip", strings.TrimSuffix(proxy, "/"), escPath, escVersion), nil
+}
+
+// parse returns the verifier key and the URL of the checksum database in the GOSUMDB setting,
+// which is either a well-known database name or '<key> [<url>]'.
+func parse(gosumdb string) (key, url string, err error) {
+	gosumdb = strings.TrimSpace(gosumdb)
+	if gosumdb == "" {
+		gosumdb = DefaultGOSUMDB
+	}
+	if gosumdb == "off" {
+		return "", "", errs.ErrSumDBDisabled
+	}
+	// sum.golang.google.cn proxies sum.golang.org for users in mainland China.
+	if gosumdb == "sum.golang.google.cn" {
+		return knownGOSUMDB[DefaultGOSUMDB], "https://sum.golang.google.cn", nil
+	}
+
+	fields := strings.Fields(gosumdb)
+	if key = knownGOSUMDB[fields[0]]; key == "" {
+		key = fields[0]
+	}
+	verifier, err := note.NewVerifier(key)
+	if err != nil || len(fields) > 2 {
+		return "", "", errs.NewMalformedGOSUMDBError(gosumdb, err)
+	}
+	if len(fields) == 2 {
+		url = fields[1]
+	} else {
+		url = verifier.Name()
+	}
+	if !strings.Contains(url, "://") {
+		url = "https://" + url
+	}
+	return key, strings.TrimSuffix(url, "/"), nil
+}
+
+// Client looks up module checksums in a Go checksum database,
+// caching the verified tiles and the latest signed tree head in a local directory.
+type Client struct {
+	name   string
+	client *sumdb.Client
+	ops    *clientOps
+}
+
+// NewClient creates a client for the checksum database in the GOSUMDB setting.
+func NewClient(gosumdb, cacheDir string) (*Client, error) {
+	key, url, err := parse(gosumdb)
+	if err != nil {
+		return nil, err
+	}
+	verifier, _ := note.NewVerifier(key)
+	ops := clientOps{
+		key:      key,
+		url:      url,
+		cacheDir: cacheDir,
+	}
+	return &Client{
+		name:   verifier.Name(),
+		client: sumdb.NewClient(&ops),
+		ops:    &ops,
+	}, nil
+}
+
+// Name returns the name of the checksum database.
+func (c *Client) Name() string {
+	return c.name
+}
+
+// Lookup returns the go.sum lines of the module version authenticated by the checksum database.
+func (c *Client) Lookup(path, version string) (lines []string, err error) {
+	if lines, err = c.client.Lookup(path, version); err != nil {
+		if msg := c.ops.securityError(); msg != "" {
+			return nil, errors.Wrap(sumdb.ErrSecurity, msg)
+		}
+		return nil, err
+	}
+	return lines, nil
+}
+
+// VerifyZip checks the module zip against the hash of the module version recorded in the checksum database.
+func (c *Client) VerifyZip(path, version, zipFile string) error {
+	lines, err := c.Lookup(path, version)
+	if err != nil {
+		return err
+	}
+	hash, err := dirhash.HashZip(zipFile, dirhash.Hash1)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	prefix := path + " " + version + " "
+	for _, line := range lines {
+		if strings.HasPrefix(line, prefix) {
+			if strings.TrimPrefix(line, prefix) == hash {
+				return nil
+			}
+			return errs.ErrSumDBMismatch
+		}
+	}
+	return errs.ErrSumDBMismatch
+}
+
+// clientOps implements sumdb.ClientOps with HTTP requests and files in the cache directory.
+type clientOps struct {
+	key      string
+	url      string
+	cacheDir string
+
+	mu          sync.Mutex
+	securityMsg string
+}
+
+func (ops *clientOps) ReadRemote(path string) ([]byte, error) {
+	return httppkg.DownloadAsBytes(ops.url + path)
+}
+
+func (ops *clientOps) ReadConfig(file string) ([]byte, error) {
+	if file == "key" {
+		return []byte(ops.key), nil
+	}
+	data, err := os.ReadFile(filepath.Join(ops.cacheDir, filepath.FromSlash(file)))
+	if os.IsNotExist(err) {
+		return nil, nil // Start with an empty signed tree.
+	}
+	return data, err
+}
+
+func (ops *clientOps) WriteConfig(file string, old, new []byte) error {
+	ops.mu.Lock()
+	defer ops.mu.Unlock()
+
+	filename := filepath.Join(ops.cacheDir, filepath.FromSlash(file))
+	cur, err := os.ReadFile(filename)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if !bytes.Equal(cur, old) {
+		return sumdb.ErrWriteConflict
+	}
+	return writeFile(filename, new)
+}
+
+func (ops *clientOps) ReadCache(file string) ([]byte, error) {
+	return os.ReadFile(filepath.Join(ops.cacheDir, filepath.FromSlash(file)))
+}
+
+func (ops *clientOps) WriteCache(file string, data []byte) {
+	_ = writeFile(filepath.Join(ops.cacheDir, filepath.FromSlash(file)), data)
+}
+
+func (ops *clientOps) Log(string) {}
+
+func (ops *clientOps) SecurityError(msg string) {
+	ops.mu.Lock()
+	defer ops.mu.Unlock()
+	ops.securityMsg = msg
+}
+
+func (ops *clientOps) securityError() string {
+	ops.mu.Lock()
+	defer ops.mu.Unlock()
+	return ops.securityMsg
+}
+
+// writeFile replaces the file atomically.
+func writeFile(filename string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(filename), 0750); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(filename), filepath.Base(filename)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err = f.Write(data); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), filename)
+}
@@ -0,0 +1,205 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package gosumdb
+
+import (
+	"archive/zip"
+	"crypto/rand"
+	"fmt"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/voidint/g/pkg/errs"
+	"golang.org/x/mod/sumdb"
+	"golang.org/x/mod/sumdb/dirhash"
+	"golang.org/x/mod/sumdb/note"
+)
+
+// newTestSumDB starts a local checksum database serving the go.sum lines and returns its GOSUMDB setting.
+func newTestSumDB(t *testing.T, gosum map[string]string) string {
+	skey, vkey, err := note.GenerateKey(rand.Reader, "sum.example.com")
+	assert.Nil(t, err)
+
+	srv := httptest.NewServer(sumdb.NewServer(sumdb.NewTestServer(skey, func(path, vers string) ([]byte, error) {
+		if lines, ok := gosum[path+"@"+vers]; ok {
+			return []byte(lines), nil
+		}
+		return nil, fmt.Errorf("%s@%s: not found", path, vers)
+	})))
+	t.Cleanup(srv.Close)
+	return vkey + " " + srv.URL
+}
+
+// writeModuleZip creates a module zip containing the specified files.
+func writeModuleZip(t *testing.T, path, version string, files map[string]string) string {
+	filename := filepath.Join(t.TempDir(), "module.zip")
+	f, err := os.Create(filename)
+	assert.Nil(t, err)
+	defer f.Close()
+
+	zw := zip.NewWriter(f)
+	for name, body := range files {
+		w, err := zw.Create(path + "@" + version + "/" + name)
+		assert.Nil(t, err)
+		_, err = w.Write([]byte(body))
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, zw.Close())
+	return filename
+}
+
+func TestToolchainVersion(t *testing.T) {
+	assert.Equal(t, "v0.0.1-go1.21.4.linux-amd64", ToolchainVersion("1.21.4", "linux", "amd64"))
+	assert.Equal(t, "v0.0.1-go1.22rc1.darwin-arm64", ToolchainVersion("1.22rc1", "darwin", "arm64"))
+}
+
+func TestProxy(t *testing.T) {
+	tests := []struct {
+		goproxy string
+		want    string
+		err     error
+	}{
+		{goproxy: "", want: DefaultGOPROXY},
+		{goproxy: "https://goproxy.cn,direct", want: "https://goproxy.cn"},
+		{goproxy: "direct|https://goproxy.io/", want: "https://goproxy.io"},
+		{goproxy: "off", err: errs.ErrModuleProxyDisabled},
+		{goproxy: "direct,off", err: errs.ErrModuleProxyDisabled},
+		{goproxy: "direct", err: errs.ErrNoModuleProxy},
+	}
+	for _, tt := range tests {
+		t.Run(tt.goproxy, func(t *testing.T) {
+			got, err := Proxy(tt.goproxy)
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestZipURL(t *testing.T) {
+	url, err := ZipURL("https://proxy.golang.org/", ToolchainModulePath, "v0.0.1-go1.21.4.linux-amd64")
+	assert.Nil(t, err)
+	assert.Equal(t, "https://proxy.golang.org/golang.org/toolchain/@v/v0.0.1-go1.21.4.linux-amd64.zip", url)
+
+	url, err = ZipURL("https://proxy.golang.org", "github.com/BurntSushi/toml", "v1.3.2")
+	assert.Nil(t, err)
+	assert.Equal(t, "https://proxy.golang.org/github.com/!burnt!sushi/toml/@v/v1.3.2.zip", url)
+}
+
+func Test_parse(t *testing.T) {
+	t.Run("默认的校验和数据库", func(t *testing.T) {
+		for _, gosumdb := range []string{"", "sum.golang.org"} {
+			key, url, err := parse(gosumdb)
+			assert.Nil(t, err)
+			assert.Equal(t, knownGOSUMDB[DefaultGOSUMDB], key)
+			assert.Equal(t, "https://sum.golang.org", url)
+		}
+	})
+
+	t.Run("国内代理", func(t *testing.T) {
+		key, url, err := parse("sum.golang.google.cn")
+		assert.Nil(t, err)
+		assert.Equal(t, knownGOSUMDB[DefaultGOSUMDB], key)
+		assert.Equal(t, "https://sum.golang.google.cn", url)
+	})
+
+	t.Run("自定义校验和数据库", func(t *testing.T) {
+		_, vkey, err := note.GenerateKey(rand.Reader, "sum.example.com")
+		assert.Nil(t, err)
+
+		key, url, err := parse(vkey)
+		assert.Nil(t, err)
+		assert.Equal(t, vkey, key)
+		assert.Equal(t, "https://sum.example.com", url)
+
+		_, url, err = parse(vkey + " sumdb.example.com/")
+		assert.Nil(t, err)
+		assert.Equal(t, "https://sumdb.example.com", url)
+
+		_, url, err = parse("sum.golang.org http://127.0.0.1:8080")
+		assert.Nil(t, err)
+		assert.Equal(t, "http://127.0.0.1:8080", url)
+	})
+
+	t.Run("关闭校验和数据库", func(t *testing.T) {
+		_, _, err := parse("off")
+		assert.Equal(t, errs.ErrSumDBDisabled, err)
+	})
+
+	t.Run("非法的配置", func(t *testing.T) {
+		_, _, err := parse("sum.example.com")
+		assert.True(t, errs.IsMalformedGOSUMDB(err))
+
+		_, _, err = parse("sum.golang.org https://a https://b")
+		assert.True(t, errs.IsMalformedGOSUMDB(err))
+	})
+}
+
+func TestClient_VerifyZip(t *testing.T) {
+	version := ToolchainVersion("1.99.0", "linux", "amd64")
+	files := map[string]string{
+		"VERSION": "go1.99.0",
+		"bin/go":  "#!/bin/sh",
+	}
+	zipFile := writeModuleZip(t, ToolchainModulePath, version, files)
+	hash, err := dirhash.HashZip(zipFile, dirhash.Hash1)
+	assert.Nil(t, err)
+
+	gosumdb := newTestSumDB(t, map[string]string{
+		ToolchainModulePath + "@" + version: fmt.Sprintf("%s %s %s\n%s %s/go.mod h1:47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=\n",
+			ToolchainModulePath, version, hash, ToolchainModulePath, version),
+	})
+	cacheDir := t.TempDir()
+
+	c, err := NewClient(gosumdb, cacheDir)
+	assert.Nil(t, err)
+	assert.Equal(t, "sum.example.com", c.Name())
+
+	t.Run("模块zip与校验和数据库一致", func(t *testing.T) {
+		assert.Nil(t, c.VerifyZip(ToolchainModulePath, version, zipFile))
+
+		// The latest signed tree head is cached for the next run.
+		_, err := os.Stat(filepath.Join(cacheDir, "sum.example.com", "latest"))
+		assert.Nil(t, err)
+	})
+
+	t.Run("模块zip被篡改", func(t *testing.T) {
+		files["bin/go"] = "#!/bin/bash"
+		tampered := writeModuleZip(t, ToolchainModulePath, version, files)
+		assert.Equal(t, errs.ErrSumDBMismatch, c.VerifyZip(ToolchainModulePath, version, tampered))
+	})
+
+	t.Run("校验和数据库中不存在", func(t *testing.T) {
+		err := c.VerifyZip(ToolchainModulePath, ToolchainVersion("1.99.0", "linux", "arm64"), zipFile)
+		assert.NotNil(t, err)
+		assert.NotEqual(t, errs.ErrSumDBMismatch, err)
+	})
+
+	t.Run("校验和数据库的签名密钥不匹配", func(t *testing.T) {
+		_, vkey, err := note.GenerateKey(rand.Reader, "sum.example.com")
+		assert.Nil(t, err)
+		c, err := NewClient(vkey+" "+strings.Fields(gosumdb)[1], t.TempDir())
+		assert.Nil(t, err)
+		assert.NotNil(t, c.VerifyZip(ToolchainModulePath, version, zipFile))
+	})
+}
@@ -96,6 +96,10 @@ func DownloadAsBytes(srcURL string) (data []byte, err error) {
 		return nil, errs.NewDownloadError(srcURL, err)
 	}
 	defer resp.Body.Close()
+
+	if !IsSuccess(resp.StatusCode) {
+		return nil, errs.NewURLUnreachableError(srcURL, fmt.Errorf("%d", resp.StatusCode))
+	}
 	return io.ReadAll(resp.Body)
 }
 
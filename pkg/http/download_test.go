@@ -173,8 +173,13 @@ func TestDownloadAsBytes(t *testing.T) {
 	rr.WriteHeader(http.StatusOK)
 	_, _ = rr.WriteString("hello world")
 
+	rr404 := httptest.NewRecorder()
+	rr404.WriteHeader(http.StatusNotFound)
+	_, _ = rr404.WriteString("404 page not found")
+
 	patches := gomonkey.ApplyMethodSeq(&http.Client{}, "Get", []gomonkey.OutputCell{
 		{Values: gomonkey.Params{nil, e}},
+		{Values: gomonkey.Params{rr404.Result(), nil}},
 		{Values: gomonkey.Params{rr.Result(), nil}},
 	})
 	defer patches.Reset()
@@ -191,6 +196,12 @@ func TestDownloadAsBytes(t *testing.T) {
 			wantData: nil,
 			wantErr:  errs.NewDownloadError(url, e),
 		},
+		{
+			name:     "资源不存在",
+			url:      url,
+			wantData: nil,
+			wantErr:  errs.NewURLUnreachableError(url, errors.New("404")),
+		},
 		{
 			name:     "发送请求并得到正常响应",
 			url:      url,
@@ -0,0 +1,154 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// Package manifest records the hashes of the files of an installed version and detects changes to them.
+package manifest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/voidint/g/pkg/checksum"
+)
+
+// Manifest maps the slash separated paths of the regular files relative to the root directory to their SHA-256 digests.
+type Manifest map[string]string
+
+// Build hashes all regular files under the root directory.
+func Build(root string) (Manifest, error) {
+	m := make(Manifest, 16384)
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		digest, err := hashFile(path)
+		if err != nil {
+			return err
+		}
+		m[filepath.ToSlash(rel)] = digest
+		return nil
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return m, nil
+}
+
+func hashFile(filename string) (string, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Load reads the manifest file written by Save.
+func Load(filename string) (Manifest, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	entries := checksum.ParseFile(data)
+	m := make(Manifest, len(entries))
+	for _, e := range entries {
+		if e.FileName != "" {
+			m[e.FileName] = e.Digest
+		}
+	}
+	return m, nil
+}
+
+// Save writes the manifest in the format of 'sha256sum' (so that it can also be checked with 'sha256sum -c').
+func (m Manifest) Save(filename string) (err error) {
+	if err = os.MkdirAll(filepath.Dir(filename), 0750); err != nil {
+		return errors.WithStack(err)
+	}
+	f, err := os.Create(filename)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if err = checksum.WriteFile(f, m); err != nil {
+		_ = f.Close()
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(f.Close())
+}
+
+// Report lists the differences between the files on disk and the manifest.
+type Report struct {
+	Modified []string `json:"modified"`
+	Missing  []string `json:"missing"`
+	Extra    []string `json:"extra"`
+}
+
+// OK reports whether the files on disk match the manifest.
+func (r *Report) OK() bool {
+	return len(r.Modified) == 0 && len(r.Missing) == 0 && len(r.Extra) == 0
+}
+
+// Verify compares the files under the root directory with the manifest.
+func (m Manifest) Verify(root string) (*Report, error) {
+	actual, err := Build(root)
+	if err != nil {
+		return nil, err
+	}
+
+	r := Report{
+		Modified: make([]string, 0),
+		Missing:  make([]string, 0),
+		Extra:    make([]string, 0),
+	}
+	for name, digest := range m {
+		got, ok := actual[name]
+		if !ok {
+			r.Missing = append(r.Missing, name)
+		} else if !strings.EqualFold(got, digest) {
+			r.Modified = append(r.Modified, name)
+		}
+	}
+	for name := range actual {
+		if _, ok := m[name]; !ok {
+			r.Extra = append(r.Extra, name)
+		}
+	}
+	sort.Strings(r.Modified)
+	sort.Strings(r.Missing)
+	sort.Strings(r.Extra)
+	return &r, nil
+}
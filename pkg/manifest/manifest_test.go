@@ -0,0 +1,110 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	for name, body := range files {
+		filename := filepath.Join(root, filepath.FromSlash(name))
+		assert.Nil(t, os.MkdirAll(filepath.Dir(filename), 0755))
+		assert.Nil(t, os.WriteFile(filename, []byte(body), 0644))
+	}
+}
+
+func TestBuild(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"VERSION":       "go1.21.4",
+		"bin/go":        "#!/bin/sh",
+		"src/fmt/go.go": "package fmt",
+	})
+	assert.Nil(t, os.MkdirAll(filepath.Join(root, "pkg", "empty"), 0755))
+
+	m, err := Build(root)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(m))
+	assert.Equal(t, "ff120ef406677f7def623daffc8819a2a0138f258ae444ad4fd4e3050bbce3a7", m["VERSION"])
+	assert.Contains(t, m, "src/fmt/go.go")
+
+	_, err = Build(filepath.Join(root, "404"))
+	assert.NotNil(t, err)
+}
+
+func TestManifest_SaveAndLoad(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"VERSION":           "go1.21.4",
+		"src/with space.go": "package main",
+	})
+	m, err := Build(root)
+	assert.Nil(t, err)
+
+	filename := filepath.Join(t.TempDir(), "manifests", "1.21.4.sha256")
+	assert.Nil(t, m.Save(filename))
+
+	loaded, err := Load(filename)
+	assert.Nil(t, err)
+	assert.Equal(t, m, loaded)
+
+	_, err = Load(filepath.Join(root, "404"))
+	assert.True(t, os.IsNotExist(errors.Cause(err)))
+}
+
+func TestManifest_Verify(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"VERSION":        "go1.21.4",
+		"bin/go":         "#!/bin/sh",
+		"src/fmt/go.go":  "package fmt",
+		"src/os/file.go": "package os",
+	})
+	m, err := Build(root)
+	assert.Nil(t, err)
+
+	t.Run("文件未被修改", func(t *testing.T) {
+		r, err := m.Verify(root)
+		assert.Nil(t, err)
+		assert.True(t, r.OK())
+	})
+
+	t.Run("文件被修改、删除和新增", func(t *testing.T) {
+		writeFiles(t, root, map[string]string{
+			"bin/go":                  "#!/bin/bash",
+			"src/vendor/modules.txt":  "# golang.org/x/net",
+			"src/fmt/vendor/extra.go": "package vendor",
+		})
+		assert.Nil(t, os.Remove(filepath.Join(root, "src", "os", "file.go")))
+
+		r, err := m.Verify(root)
+		assert.Nil(t, err)
+		assert.False(t, r.OK())
+		assert.Equal(t, []string{"bin/go"}, r.Modified)
+		assert.Equal(t, []string{"src/os/file.go"}, r.Missing)
+		assert.Equal(t, []string{"src/fmt/vendor/extra.go", "src/vendor/modules.txt"}, r.Extra)
+	})
+}
@@ -0,0 +1,361 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// Package policy enforces the installation policy of an organization or project on Go versions.
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/voidint/g/pkg/errs"
+	"github.com/voidint/g/version"
+)
+
+// ProjectFile is the name of the per-project policy file, which is searched for from the working directory upwards.
+const ProjectFile = ".g-policy.json"
+
+// Names of the policy rules, which are reported by the violation errors.
+const (
+	RuleMinVersion       = "min_version"
+	RuleMaxVersion       = "max_version"
+	RuleDeny             = "deny"
+	RuleRecentMinors     = "recent_minors"
+	RuleRequireChecksum  = "require_checksum"
+	RuleRequireSignature = "require_signature"
+	RuleAllowedMirrors   = "allowed_mirrors"
+)
+
+// Rules is the content of a policy file.
+type Rules struct {
+	// MinVersion is the oldest version allowed, e.g. '1.21.0'.
+	MinVersion string `json:"min_version,omitempty"`
+	// MaxVersion is the newest version allowed, e.g. '1.22.99'.
+	MaxVersion string `json:"max_version,omitempty"`
+	// Deny lists the versions (e.g. '1.21.0') or version constraints (e.g. '< 1.20.12') that are forbidden.
+	Deny []string `json:"deny,omitempty"`
+	// RecentMinors only allows the versions of the latest N minor releases, e.g. 2 allows 1.22.x and 1.21.x when 1.22 is the latest.
+	RecentMinors int `json:"recent_minors,omitempty"`
+	// RequireChecksum forbids installing packages whose checksum cannot be verified.
+	RequireChecksum bool `json:"require_checksum,omitempty"`
+	// RequireSignature forbids installing packages without a valid OpenPGP signature.
+	RequireSignature bool `json:"require_signature,omitempty"`
+	// AllowedMirrors lists the download pages (see G_MIRROR) that versions may be installed from.
+	AllowedMirrors []string `json:"allowed_mirrors,omitempty"`
+}
+
+type source struct {
+	file  string
+	rules Rules
+}
+
+// Policy combines the rules of the global and per-project policy files. A version must satisfy all of them.
+type Policy struct {
+	sources []source
+}
+
+// Load reads the policy files that exist. Missing files are ignored.
+func Load(files ...string) (*Policy, error) {
+	var p Policy
+	for _, filename := range files {
+		if filename == "" {
+			continue
+		}
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, errors.WithStack(err)
+		}
+		var rules Rules
+		if err = json.Unmarshal(data, &rules); err != nil {
+			return nil, errs.NewMalformedPolicyError(filename, err)
+		}
+		if err = rules.validate(); err != nil {
+			return nil, errs.NewMalformedPolicyError(filename, err)
+		}
+		p.sources = append(p.sources, source{file: filename, rules: rules})
+	}
+	return &p, nil
+}
+
+func (r *Rules) validate() error {
+	for _, vname := range []string{r.MinVersion, r.MaxVersion} {
+		if vname == "" {
+			continue
+		}
+		if _, err := version.Semantify(vname); err != nil {
+			return err
+		}
+	}
+	for _, item := range r.Deny {
+		if _, err := parseDeny(item); err != nil {
+			return err
+		}
+	}
+	if r.RecentMinors < 0 {
+		return fmt.Errorf("%s must not be negative", RuleRecentMinors)
+	}
+	return nil
+}
+
+// FindProjectFile returns the nearest per-project policy file in the directory or its ancestors.
+func FindProjectFile(dir string) (filename string, ok bool) {
+	for {
+		filename = filepath.Join(dir, ProjectFile)
+		if fi, err := os.Stat(filename); err == nil && !fi.IsDir() {
+			return filename, true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// Files returns the policy files in effect.
+func (p *Policy) Files() []string {
+	files := make([]string, 0, len(p.sources))
+	for _, s := range p.sources {
+		files = append(files, s.file)
+	}
+	return files
+}
+
+// Empty reports whether no policy is in effect.
+func (p *Policy) Empty() bool {
+	return p == nil || len(p.sources) == 0
+}
+
+// NeedsLatest reports whether CheckVersion needs to know the latest stable version.
+func (p *Policy) NeedsLatest() bool {
+	if p == nil {
+		return false
+	}
+	for _, s := range p.sources {
+		if s.rules.RecentMinors > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// RequireChecksum reports whether packages must pass checksum verification.
+func (p *Policy) RequireChecksum() (file string, yes bool) {
+	return p.find(func(r *Rules) bool { return r.RequireChecksum })
+}
+
+// RequireSignature reports whether packages must pass signature verification.
+func (p *Policy) RequireSignature() (file string, yes bool) {
+	return p.find(func(r *Rules) bool { return r.RequireSignature })
+}
+
+func (p *Policy) find(fn func(r *Rules) bool) (file string, yes bool) {
+	if p == nil {
+		return "", false
+	}
+	for i := range p.sources {
+		if fn(&p.sources[i].rules) {
+			return p.sources[i].file, true
+		}
+	}
+	return "", false
+}
+
+// CheckVersion checks the version against the version rules.
+// The latest stable version is only needed by the 'recent_minors' rule and may be empty otherwise.
+func (p *Policy) CheckVersion(vname, latest string) error {
+	return p.checkVersion(vname, latest, true)
+}
+
+// CheckLocalVersion checks the version against the version rules needing no network, i.e. all but the 'recent_minors' rule.
+// It suits the paths run on every command or prompt, such as the shims and the shell hook.
+func (p *Policy) CheckLocalVersion(vname string) error {
+	return p.checkVersion(vname, "", false)
+}
+
+// checkVersion compares the versions by the Go toolchain version rules, so that the rules apply to the release candidates
+// and betas too, e.g. '1.20rc1' is older than '1.20.0' and is denied by '< 1.20.12'.
+func (p *Policy) checkVersion(vname, latest string, recentMinors bool) error {
+	if p == nil {
+		return nil
+	}
+	v, err := version.Semantify(vname)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range p.sources {
+		r := s.rules
+		if r.MinVersion != "" && version.Compare(vname, r.MinVersion) < 0 {
+			return errs.NewPolicyViolationError(s.file, RuleMinVersion, vname, fmt.Sprintf("versions older than %s are not allowed", r.MinVersion))
+		}
+		if r.MaxVersion != "" && version.Compare(vname, r.MaxVersion) > 0 {
+			return errs.NewPolicyViolationError(s.file, RuleMaxVersion, vname, fmt.Sprintf("versions newer than %s are not allowed", r.MaxVersion))
+		}
+		for _, item := range r.Deny {
+			if d, _ := parseDeny(item); d.match(vname) {
+				return errs.NewPolicyViolationError(s.file, RuleDeny, vname, fmt.Sprintf("denied by %q", item))
+			}
+		}
+		if r.RecentMinors > 0 && recentMinors {
+			if latest == "" {
+				return errs.NewPolicyViolationError(s.file, RuleRecentMinors, vname, "the latest stable version is unknown")
+			}
+			l, err := version.Semantify(latest)
+			if err != nil {
+				return err
+			}
+			if v.Major() != l.Major() || v.Minor()+uint64(r.RecentMinors) <= l.Minor() {
+				return errs.NewPolicyViolationError(s.file, RuleRecentMinors, vname,
+					fmt.Sprintf("only the latest %d minor releases (go%d.%d is the latest) are allowed", r.RecentMinors, l.Major(), l.Minor()))
+			}
+		}
+	}
+	return nil
+}
+
+// CheckMirrors checks the download pages (in the format of G_MIRROR) against the 'allowed_mirrors' rule.
+func (p *Policy) CheckMirrors(mirrors []string) error {
+	if p == nil {
+		return nil
+	}
+	for _, s := range p.sources {
+		if len(s.rules.AllowedMirrors) == 0 {
+			continue
+		}
+		allowed := make(map[string]bool, len(s.rules.AllowedMirrors))
+		for _, m := range s.rules.AllowedMirrors {
+			allowed[normalizeMirror(m)] = true
+		}
+		for _, m := range mirrors {
+			if !allowed[normalizeMirror(m)] {
+				return errs.NewPolicyViolationError(s.file, RuleAllowedMirrors, "", fmt.Sprintf("mirror %q is not allowed", m))
+			}
+		}
+	}
+	return nil
+}
+
+// normalizeMirror strips the collector name and unifies the trailing slash, e.g. 'official|https://go.dev/dl' => 'https://go.dev/dl/'.
+func normalizeMirror(mirror string) string {
+	mirror = strings.TrimSpace(mirror)
+	if idx := strings.Index(mirror, "|"); idx >= 0 {
+		mirror = strings.TrimSpace(mirror[idx+1:])
+	}
+	return strings.TrimSuffix(mirror, "/") + "/"
+}
+
+// denyItem is a denied version, or a version constraint whose comparisons (e.g. '>= 1.20.2, < 1.20.5 || 1.21.0') are
+// evaluated by the Go toolchain version rules. Other constraints (e.g. '1.20.x', '~1.20') take the release candidates and
+// betas to satisfy them if the release they precede does.
+type denyItem struct {
+	vname string
+	// alternatives holds the comparisons that must all hold, one list per alternative.
+	alternatives [][]comparison
+	c            *version.Constraint
+}
+
+type comparison struct {
+	op    string
+	vname string
+}
+
+var comparisonReg = regexp.MustCompile(`(<=|>=|!=|==|=|<|>)\s*(go\d[0-9A-Za-z.]*|\d[0-9A-Za-z.]*)`)
+
+func parseDeny(item string) (d denyItem, err error) {
+	item = strings.TrimSpace(item)
+	if _, err = version.Semantify(strings.TrimPrefix(item, "go")); err == nil {
+		d.vname = item
+		return d, nil
+	}
+	if d.alternatives = parseComparisons(item); d.alternatives != nil {
+		return d, nil
+	}
+	if d.c, err = version.NewConstraint(item, version.WithPrerelease()); err != nil {
+		return d, err
+	}
+	return d, nil
+}
+
+// parseComparisons returns the comparisons of the constraint, or nil if it holds anything else.
+func parseComparisons(expr string) (alternatives [][]comparison) {
+	for _, alt := range strings.Split(expr, "||") {
+		var cmps []comparison
+		for _, sm := range comparisonReg.FindAllStringSubmatch(alt, -1) {
+			if !version.IsValid(sm[2]) {
+				return nil
+			}
+			cmps = append(cmps, comparison{op: sm[1], vname: sm[2]})
+		}
+		if len(cmps) == 0 || strings.Trim(comparisonReg.ReplaceAllString(alt, ""), " ,") != "" {
+			return nil
+		}
+		alternatives = append(alternatives, cmps)
+	}
+	return alternatives
+}
+
+func (d denyItem) match(vname string) bool {
+	if d.vname != "" {
+		return version.Compare(vname, d.vname) == 0
+	}
+	if d.c != nil {
+		v, err := version.New(vname)
+		return err == nil && d.c.Check(v)
+	}
+	for _, cmps := range d.alternatives {
+		if allHold(vname, cmps) {
+			return true
+		}
+	}
+	return false
+}
+
+func allHold(vname string, cmps []comparison) bool {
+	for _, cmp := range cmps {
+		c := version.Compare(vname, cmp.vname)
+		var ok bool
+		switch cmp.op {
+		case "<":
+			ok = c < 0
+		case "<=":
+			ok = c <= 0
+		case ">":
+			ok = c > 0
+		case ">=":
+			ok = c >= 0
+		case "!=":
+			ok = c != 0
+		default:
+			ok = c == 0
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
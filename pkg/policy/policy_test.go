@@ -0,0 +1,191 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/voidint/g/pkg/errs"
+)
+
+func writePolicy(t *testing.T, dir, content string) string {
+	filename := filepath.Join(dir, ProjectFile)
+	assert.Nil(t, os.WriteFile(filename, []byte(content), 0600))
+	return filename
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("忽略不存在的策略文件", func(t *testing.T) {
+		p, err := Load("", filepath.Join(dir, "nonexistent.json"))
+		assert.Nil(t, err)
+		assert.True(t, p.Empty())
+		assert.Empty(t, p.Files())
+	})
+
+	t.Run("格式错误的策略文件", func(t *testing.T) {
+		for _, content := range []string{
+			`{`,
+			`{"min_version": "abc"}`,
+			`{"deny": ["~~1.21"]}`,
+			`{"recent_minors": -1}`,
+		} {
+			_, err := Load(writePolicy(t, t.TempDir(), content))
+			assert.True(t, errs.IsMalformedPolicy(err), content)
+		}
+	})
+
+	t.Run("加载多个策略文件", func(t *testing.T) {
+		global := filepath.Join(dir, "policy.json")
+		assert.Nil(t, os.WriteFile(global, []byte(`{"require_checksum": true}`), 0600))
+		project := writePolicy(t, dir, `{"require_signature": true}`)
+
+		p, err := Load(global, project)
+		assert.Nil(t, err)
+		assert.False(t, p.Empty())
+		assert.Equal(t, []string{global, project}, p.Files())
+
+		file, yes := p.RequireChecksum()
+		assert.True(t, yes)
+		assert.Equal(t, global, file)
+		file, yes = p.RequireSignature()
+		assert.True(t, yes)
+		assert.Equal(t, project, file)
+	})
+}
+
+func TestFindProjectFile(t *testing.T) {
+	root := t.TempDir()
+	sub := filepath.Join(root, "a", "b")
+	assert.Nil(t, os.MkdirAll(sub, 0755))
+
+	_, ok := FindProjectFile(sub)
+	assert.False(t, ok)
+
+	expected := writePolicy(t, root, `{}`)
+	filename, ok := FindProjectFile(sub)
+	assert.True(t, ok)
+	assert.Equal(t, expected, filename)
+}
+
+func TestPolicy_CheckVersion(t *testing.T) {
+	filename := writePolicy(t, t.TempDir(), `{
+	"min_version": "1.20.0",
+	"max_version": "1.22.99",
+	"deny": ["1.21.0", "go1.20.1", ">= 1.20.2, < 1.20.5"],
+	"recent_minors": 2
+}`)
+	p, err := Load(filename)
+	assert.Nil(t, err)
+	assert.True(t, p.NeedsLatest())
+
+	tests := []struct {
+		name   string
+		vname  string
+		latest string
+		rule   string
+	}{
+		{name: "满足所有规则", vname: "1.21.4", latest: "1.22.0"},
+		{name: "低于最低版本", vname: "1.19.13", latest: "1.20.0", rule: RuleMinVersion},
+		{name: "高于最高版本", vname: "1.23.0", latest: "1.23.0", rule: RuleMaxVersion},
+		{name: "明确禁止的版本", vname: "1.21.0", latest: "1.22.0", rule: RuleDeny},
+		{name: "带go前缀的禁止版本", vname: "1.20.1", latest: "1.21.0", rule: RuleDeny},
+		{name: "禁止的版本范围", vname: "1.20.3", latest: "1.21.0", rule: RuleDeny},
+		{name: "超出最近的次版本", vname: "1.20.14", latest: "1.22.0", rule: RuleRecentMinors},
+		{name: "最新版本未知", vname: "1.22.0", latest: "", rule: RuleRecentMinors},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := p.CheckVersion(tt.vname, tt.latest)
+			if tt.rule == "" {
+				assert.Nil(t, err)
+				return
+			}
+			e, ok := err.(*errs.PolicyViolationError)
+			assert.True(t, ok)
+			assert.Equal(t, tt.rule, e.Rule())
+			assert.Equal(t, filename, e.File())
+			assert.Equal(t, tt.vname, e.Version())
+		})
+	}
+
+	t.Run("仅检查本地规则", func(t *testing.T) {
+		assert.Nil(t, p.CheckLocalVersion("1.20.14"))
+		e, ok := p.CheckLocalVersion("1.21.0").(*errs.PolicyViolationError)
+		assert.True(t, ok)
+		assert.Equal(t, RuleDeny, e.Rule())
+		e, ok = p.CheckLocalVersion("1.19.13").(*errs.PolicyViolationError)
+		assert.True(t, ok)
+		assert.Equal(t, RuleMinVersion, e.Rule())
+	})
+
+	t.Run("规则同样适用于预发布版本", func(t *testing.T) {
+		p, err := Load(writePolicy(t, t.TempDir(), `{
+	"min_version": "1.19.0",
+	"max_version": "1.22.99",
+	"deny": ["< 1.20.12", "1.21.x", "go1.22rc1"]
+}`))
+		assert.Nil(t, err)
+
+		for vname, rule := range map[string]string{
+			"1.20rc1":   RuleDeny,
+			"1.20beta1": RuleDeny,
+			"1.20.11":   RuleDeny,
+			"1.21rc2":   RuleDeny,
+			"1.22rc1":   RuleDeny,
+			"1.19rc1":   RuleMinVersion,
+			"1.23rc1":   RuleMaxVersion,
+			"1.20.12":   "",
+			"1.22rc2":   "",
+			"1.22.0":    "",
+		} {
+			err := p.CheckLocalVersion(vname)
+			if rule == "" {
+				assert.Nil(t, err, vname)
+				continue
+			}
+			e, ok := err.(*errs.PolicyViolationError)
+			assert.True(t, ok, vname)
+			assert.Equal(t, rule, e.Rule(), vname)
+		}
+	})
+
+	var empty *Policy
+	assert.Nil(t, empty.CheckVersion("1.0", ""))
+	assert.Nil(t, empty.CheckLocalVersion("1.0"))
+	assert.False(t, empty.NeedsLatest())
+}
+
+func TestPolicy_CheckMirrors(t *testing.T) {
+	p, err := Load(writePolicy(t, t.TempDir(), `{"allowed_mirrors": ["https://go.dev/dl", "https://golang.google.cn/dl/"]}`))
+	assert.Nil(t, err)
+
+	assert.Nil(t, p.CheckMirrors([]string{"https://go.dev/dl/"}))
+	assert.Nil(t, p.CheckMirrors([]string{"official|https://golang.google.cn/dl"}))
+
+	err = p.CheckMirrors([]string{"https://go.dev/dl/", "https://mirrors.example.com/golang/"})
+	e, ok := err.(*errs.PolicyViolationError)
+	assert.True(t, ok)
+	assert.Equal(t, RuleAllowedMirrors, e.Rule())
+}
@@ -0,0 +1,369 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// Package project selects the Go toolchain of a project the way the go command does.
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/voidint/g/pkg/errs"
+	"github.com/voidint/g/version"
+)
+
+const (
+	// GoWorkFile is the name of the workspace file.
+	GoWorkFile = "go.work"
+	// GoModFile is the name of the module file.
+	GoModFile = "go.mod"
+	// GoVersionFile is the name of the version file holding a single version, e.g. '1.22.3'.
+	GoVersionFile = ".go-version"
+	// ToolVersionsFile is the name of the asdf version file, e.g. with the line 'golang 1.22.3'.
+	ToolVersionsFile = ".tool-versions"
+	// goworkEnv is the environment variable of the go command disabling or specifying the workspace file.
+	goworkEnv = "GOWORK"
+)
+
+// Names of the directives selecting the Go version.
+const (
+	GoDirective        = "go"
+	ToolchainDirective = "toolchain"
+)
+
+// Selection is the Go version selected by a project file.
+type Selection struct {
+	// File is the project file deciding the version.
+	File string
+	// Directive is the directive of the go.work or go.mod file deciding the version, i.e. 'go' or 'toolchain'.
+	// It is empty for the version files.
+	Directive string
+	// Version is the selected version, e.g. '1.22.3'. It is the minimum version allowed if Minimum is true.
+	// The version files may also name a version constraint, e.g. '1.22' or '~1.21'.
+	Version string
+	// Minimum reports whether any version not older than Version is acceptable.
+	Minimum bool
+}
+
+// Allows reports whether the version satisfies the selection.
+func (s *Selection) Allows(vname string) bool {
+	if vname == s.Version {
+		return true
+	}
+	if !s.Minimum {
+		return false
+	}
+	if !version.IsValid(vname) || !version.IsValid(s.Version) {
+		return false
+	}
+	if version.IsPrerelease(vname) && !version.IsPrerelease(s.Version) {
+		return false // prereleases are only acceptable if the minimum version is a prerelease too
+	}
+	return version.Compare(vname, s.Version) >= 0
+}
+
+// Select returns the Go version selected by the nearest project file in the directory or its ancestors.
+// The version files (.go-version or .tool-versions) win over the go.work and go.mod files in the same directory.
+// Once a go.work or go.mod file is the nearest, the version is decided the way Find does, e.g. by the workspace containing the module.
+func Select(dir string) (*Selection, error) {
+	goFiles := []string{GoModFile}
+	if gowork := os.Getenv(goworkEnv); gowork == "" || gowork == "auto" {
+		goFiles = append(goFiles, GoWorkFile)
+	}
+	for cur := dir; ; {
+		sel, err := findVersionFileIn(cur)
+		if err == nil || !errors.Is(err, errs.ErrProjectFileNotFound) {
+			return sel, err
+		}
+		for _, name := range goFiles {
+			if isFile(filepath.Join(cur, name)) {
+				return Find(dir)
+			}
+		}
+		parent := filepath.Dir(cur)
+		if parent == cur {
+			return Find(dir)
+		}
+		cur = parent
+	}
+}
+
+// FindVersionFile searches the directory and its ancestors for the .go-version file or the .tool-versions file with a Go version.
+// The .go-version file is preferred in the same directory.
+func FindVersionFile(dir string) (*Selection, error) {
+	for {
+		sel, err := findVersionFileIn(dir)
+		if err == nil || !errors.Is(err, errs.ErrProjectFileNotFound) {
+			return sel, err
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, errs.ErrProjectFileNotFound
+		}
+		dir = parent
+	}
+}
+
+// findVersionFileIn returns the Go version named by the version file in the directory itself.
+func findVersionFileIn(dir string) (*Selection, error) {
+	for _, name := range []string{GoVersionFile, ToolVersionsFile} {
+		filename := filepath.Join(dir, name)
+		if !isFile(filename) {
+			continue
+		}
+		sel, err := ParseVersionFile(filename)
+		if err != nil && errors.Is(err, errs.ErrGoDirectiveNotFound) && name == ToolVersionsFile {
+			continue // other tools only
+		}
+		return sel, err
+	}
+	return nil, errs.ErrProjectFileNotFound
+}
+
+// isFile reports whether the file exists and is not a directory.
+func isFile(filename string) bool {
+	fi, err := os.Stat(filename)
+	return err == nil && !fi.IsDir()
+}
+
+// ParseVersionFile returns the Go version named by the .go-version or .tool-versions file.
+func ParseVersionFile(filename string) (*Selection, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var vname string
+	if filepath.Base(filename) == ToolVersionsFile {
+		vname = getToolVersion(data)
+	} else {
+		for _, line := range strings.Split(string(data), "\n") {
+			if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+				vname = line
+				break
+			}
+		}
+	}
+	if vname = strings.TrimPrefix(vname, "go"); vname == "" {
+		return nil, errors.Wrapf(errs.ErrGoDirectiveNotFound, "%q", filename)
+	}
+	return &Selection{File: filename, Version: vname}, nil
+}
+
+// toolNames are the names of Go in .tool-versions files used by asdf ('golang') and mise ('go').
+var toolNames = map[string]bool{"golang": true, "go": true}
+
+// getToolVersion returns the first Go version in the .tool-versions file.
+func getToolVersion(data []byte) string {
+	for _, line := range strings.Split(string(data), "\n") {
+		if idx := strings.Index(line, "#"); idx >= 0 {
+			line = line[:idx]
+		}
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && toolNames[fields[0]] {
+			return fields[1]
+		}
+	}
+	return ""
+}
+
+// WriteVersionFile records the version in the version file that Select reads in the directory. An existing .go-version file
+// is updated (keeping its comments). Otherwise the version of the 'golang' line of an existing .tool-versions file is
+// updated (keeping the rest of the line, e.g. the comment) or the line is added, and failing both the .go-version file is written.
+// It returns the file written.
+func WriteVersionFile(dir, vname string) (filename string, err error) {
+	if filename = filepath.Join(dir, GoVersionFile); isFile(filename) {
+		return filename, writeGoVersionFile(filename, vname)
+	}
+
+	filename = filepath.Join(dir, ToolVersionsFile)
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return "", errors.WithStack(err)
+		}
+		filename = filepath.Join(dir, GoVersionFile)
+		return filename, errors.WithStack(os.WriteFile(filename, []byte(vname+"\n"), 0644))
+	}
+
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	var found bool
+	for i, line := range lines {
+		code := line
+		if idx := strings.Index(line, "#"); idx >= 0 {
+			code = line[:idx]
+		}
+		if fields := strings.Fields(code); len(fields) >= 2 && toolNames[fields[0]] && !found {
+			start := strings.Index(code, fields[0]) + len(fields[0])
+			start += strings.Index(code[start:], fields[1])
+			lines[i] = line[:start] + vname + line[start+len(fields[1]):]
+			found = true
+		}
+	}
+	if !found {
+		lines = append(lines, "golang "+vname)
+	}
+	return filename, errors.WithStack(os.WriteFile(filename, []byte(strings.Join(lines, "\n")+"\n"), 0644))
+}
+
+// writeGoVersionFile replaces the version in the existing .go-version file, keeping its comments.
+func writeGoVersionFile(filename, vname string) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	var found bool
+	for i, line := range lines {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+			lines[i] = vname
+			found = true
+			break
+		}
+	}
+	if !found {
+		lines = append(lines, vname)
+	}
+	return errors.WithStack(os.WriteFile(filename, []byte(strings.Join(lines, "\n")+"\n"), 0644))
+}
+
+// Find searches the directory and its ancestors for the go.work file, then for the go.mod file,
+// and returns the Go version they select. GOWORK=off disables the workspace file and GOWORK=<file> specifies it.
+func Find(dir string) (*Selection, error) {
+	filename, err := findGoFile(dir)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(filename)
+}
+
+// FindMinVersion returns the oldest release satisfying the go directive of the go.work or go.mod file found by Find,
+// regardless of the toolchain directive.
+func FindMinVersion(dir string) (string, error) {
+	filename, err := findGoFile(dir)
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	goVersion := GetGoDirective(data)
+	if goVersion == "" {
+		return "", errors.Wrapf(errs.ErrGoDirectiveNotFound, "%q", filename)
+	}
+	return MinVersion(goVersion), nil
+}
+
+// findGoFile returns the go.work or go.mod file in effect for the directory.
+func findGoFile(dir string) (string, error) {
+	switch gowork := os.Getenv(goworkEnv); gowork {
+	case "off":
+	case "", "auto":
+		if filename, ok := findUp(dir, GoWorkFile); ok {
+			return filename, nil
+		}
+	default:
+		return gowork, nil
+	}
+
+	if filename, ok := findUp(dir, GoModFile); ok {
+		return filename, nil
+	}
+	return "", errs.ErrProjectFileNotFound
+}
+
+// findUp returns the nearest file with the name in the directory or its ancestors.
+func findUp(dir, name string) (filename string, ok bool) {
+	for {
+		filename = filepath.Join(dir, name)
+		if isFile(filename) {
+			return filename, true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// Parse returns the Go version selected by the go.work or go.mod file.
+// The toolchain directive is preferred unless it is older than the go directive.
+// Like the go directive, it names a minimum version: the go command runs any toolchain not older than it.
+func Parse(filename string) (*Selection, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	goVersion := GetGoDirective(data)
+	if toolchain := GetToolchainDirective(data); toolchain != "" {
+		if goVersion == "" || !older(toolchain, MinVersion(goVersion)) {
+			return &Selection{File: filename, Directive: ToolchainDirective, Version: toolchain, Minimum: true}, nil
+		}
+	}
+	if goVersion == "" {
+		return nil, errors.Wrapf(errs.ErrGoDirectiveNotFound, "%q", filename)
+	}
+	return &Selection{File: filename, Directive: GoDirective, Version: MinVersion(goVersion), Minimum: true}, nil
+}
+
+func older(a, b string) bool {
+	return version.Compare(a, b) < 0
+}
+
+// MinVersion returns the oldest release satisfying the go directive.
+// Since Go 1.21 the go directive names a language version, e.g. 'go 1.21' is satisfied by 1.21.0 and newer releases.
+func MinVersion(goVersion string) string {
+	if version.IsPrerelease(goVersion) || strings.Count(goVersion, ".") != 1 || version.Compare(goVersion, "1.21") < 0 {
+		return goVersion
+	}
+	return goVersion + ".0"
+}
+
+var (
+	goDirectiveReg        = regexp.MustCompile(`(?m)^go\s+(\d+\.\d+(?:\.\d+)?(?:beta\d+|rc\d+)?)\s*(?:$|//.*)`)
+	toolchainDirectiveReg = regexp.MustCompile(`(?m)^toolchain\s+go(\d+\.\d+(?:\.\d+)?(?:beta\d+|rc\d+)?)(?:[-+]\S*)?\s*(?:$|//.*)`)
+)
+
+// GetGoDirective Extract the go directive from the go.mod or go.work file.
+func GetGoDirective(data []byte) string {
+	// https://go.dev/ref/mod#go-mod-file-go
+	match := goDirectiveReg.FindStringSubmatch(string(data))
+	if len(match) > 1 {
+		return match[1]
+	}
+	return ""
+}
+
+// GetToolchainDirective Extract the version of the toolchain directive from the go.mod or go.work file,
+// e.g. '1.22.3' for 'toolchain go1.22.3'. The 'default' and 'local' toolchains select no version.
+func GetToolchainDirective(data []byte) string {
+	// https://go.dev/ref/mod#go-mod-file-toolchain
+	match := toolchainDirectiveReg.FindStringSubmatch(string(data))
+	if len(match) > 1 {
+		return match[1]
+	}
+	return ""
+}
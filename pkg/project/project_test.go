@@ -0,0 +1,400 @@
+// Copyright (c) 2019 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/voidint/g/pkg/errs"
+)
+
+func TestGetGoDirective(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []byte
+		expected string
+	}{
+		{
+			name:     "normal go directive",
+			input:    []byte("module github.com/voidint/g\n\ngo 1.20\n"),
+			expected: "1.20",
+		},
+		{
+			name:     "normal go directive",
+			input:    []byte("module github.com/voidint/g\n\ngo 1.24.0\n"),
+			expected: "1.24.0",
+		},
+		{
+			name:     "normal go directive",
+			input:    []byte("module github.com/voidint/g\n\ngo 1.24.4\n"),
+			expected: "1.24.4",
+		},
+		{
+			name:     "normal go directive",
+			input:    []byte("module github.com/voidint/g\n\ngo 1.25rc1\n"),
+			expected: "1.25rc1",
+		},
+		{
+			name:     "no go directive",
+			input:    []byte("module github.com/voidint/g\n"),
+			expected: "",
+		},
+		{
+			name:     "malformed directive",
+			input:    []byte("go1.20"),
+			expected: "",
+		},
+		{
+			name:     "empty input",
+			input:    []byte(""),
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GetGoDirective(tt.input)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestGetToolchainDirective(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []byte
+		expected string
+	}{
+		{
+			name:     "normal toolchain directive",
+			input:    []byte("module github.com/voidint/g\n\ngo 1.21\n\ntoolchain go1.22.3\n"),
+			expected: "1.22.3",
+		},
+		{
+			name:     "prerelease toolchain",
+			input:    []byte("go 1.21\ntoolchain go1.22rc1 // comment\n"),
+			expected: "1.22rc1",
+		},
+		{
+			name:     "custom toolchain suffix",
+			input:    []byte("go 1.21\ntoolchain go1.21.3-custom\n"),
+			expected: "1.21.3",
+		},
+		{
+			name:     "default toolchain",
+			input:    []byte("go 1.21\ntoolchain default\n"),
+			expected: "",
+		},
+		{
+			name:     "no toolchain directive",
+			input:    []byte("module github.com/voidint/g\n\ngo 1.20\n"),
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, GetToolchainDirective(tt.input))
+		})
+	}
+}
+
+func TestMinVersion(t *testing.T) {
+	assert.Equal(t, "1.21.0", MinVersion("1.21"))
+	assert.Equal(t, "1.22.0", MinVersion("1.22"))
+	assert.Equal(t, "1.21.3", MinVersion("1.21.3"))
+	assert.Equal(t, "1.21rc1", MinVersion("1.21rc1"))
+	assert.Equal(t, "1.20", MinVersion("1.20"))
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected *Selection
+		wantErr  error
+	}{
+		{
+			name:     "优先使用toolchain指令",
+			content:  "module x\n\ngo 1.21\n\ntoolchain go1.22.3\n",
+			expected: &Selection{Directive: ToolchainDirective, Version: "1.22.3", Minimum: true},
+		},
+		{
+			name:     "忽略低于go指令的toolchain指令",
+			content:  "module x\n\ngo 1.22.1\n\ntoolchain go1.21.0\n",
+			expected: &Selection{Directive: GoDirective, Version: "1.22.1", Minimum: true},
+		},
+		{
+			name:     "go指令为最低版本",
+			content:  "module x\n\ngo 1.21\n",
+			expected: &Selection{Directive: GoDirective, Version: "1.21.0", Minimum: true},
+		},
+		{
+			name:     "仅有toolchain指令",
+			content:  "module x\n\ntoolchain go1.22.3\n",
+			expected: &Selection{Directive: ToolchainDirective, Version: "1.22.3", Minimum: true},
+		},
+		{
+			name:    "缺少go指令",
+			content: "module x\n",
+			wantErr: errs.ErrGoDirectiveNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), GoModFile)
+			assert.Nil(t, os.WriteFile(filename, []byte(tt.content), 0600))
+
+			sel, err := Parse(filename)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.Nil(t, err)
+			tt.expected.File = filename
+			assert.Equal(t, tt.expected, sel)
+		})
+	}
+}
+
+func TestFind(t *testing.T) {
+	root := t.TempDir()
+	mod := filepath.Join(root, "mod")
+	sub := filepath.Join(mod, "internal", "pkg")
+	assert.Nil(t, os.MkdirAll(sub, 0755))
+	assert.Nil(t, os.WriteFile(filepath.Join(mod, GoModFile), []byte("module x\n\ngo 1.21.3\n"), 0600))
+
+	t.Setenv(goworkEnv, "")
+
+	t.Run("向上查找go.mod", func(t *testing.T) {
+		sel, err := Find(sub)
+		assert.Nil(t, err)
+		assert.Equal(t, filepath.Join(mod, GoModFile), sel.File)
+		assert.Equal(t, "1.21.3", sel.Version)
+	})
+
+	t.Run("未找到项目文件", func(t *testing.T) {
+		_, err := Find(root)
+		assert.ErrorIs(t, err, errs.ErrProjectFileNotFound)
+	})
+
+	assert.Nil(t, os.WriteFile(filepath.Join(root, GoWorkFile), []byte("go 1.22\n\nuse ./mod\n"), 0600))
+
+	t.Run("go.work优先于go.mod", func(t *testing.T) {
+		sel, err := Find(sub)
+		assert.Nil(t, err)
+		assert.Equal(t, filepath.Join(root, GoWorkFile), sel.File)
+		assert.Equal(t, "1.22.0", sel.Version)
+	})
+
+	t.Run("GOWORK=off禁用go.work", func(t *testing.T) {
+		t.Setenv(goworkEnv, "off")
+		sel, err := Find(sub)
+		assert.Nil(t, err)
+		assert.Equal(t, filepath.Join(mod, GoModFile), sel.File)
+	})
+}
+
+func TestFindMinVersion(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(goworkEnv, "off")
+
+	_, err := FindMinVersion(dir)
+	assert.ErrorIs(t, err, errs.ErrProjectFileNotFound)
+
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, GoModFile), []byte("module x\n\ngo 1.22\n\ntoolchain go1.23.4\n"), 0600))
+	min, err := FindMinVersion(dir)
+	assert.Nil(t, err)
+	assert.Equal(t, "1.22.0", min)
+
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, GoModFile), []byte("module x\n\ntoolchain go1.23.4\n"), 0600))
+	_, err = FindMinVersion(dir)
+	assert.ErrorIs(t, err, errs.ErrGoDirectiveNotFound)
+}
+
+func TestSelection_Allows(t *testing.T) {
+	min := &Selection{Version: "1.21.0", Minimum: true}
+	assert.True(t, min.Allows("1.21.0"))
+	assert.True(t, min.Allows("1.22.3"))
+	assert.False(t, min.Allows("1.20.14"))
+	assert.False(t, min.Allows("1.22rc1"))
+	assert.False(t, min.Allows("abc"))
+
+	exact := &Selection{Version: "1.22.3"}
+	assert.True(t, exact.Allows("1.22.3"))
+	assert.False(t, exact.Allows("1.22.4"))
+}
+
+func TestParseVersionFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		content  string
+		expected string
+		wantErr  bool
+	}{
+		{name: ".go-version文件", file: GoVersionFile, content: "1.22.3\n", expected: "1.22.3"},
+		{name: "带go前缀和注释的.go-version文件", file: GoVersionFile, content: "# pinned\ngo1.21\n", expected: "1.21"},
+		{name: "空的.go-version文件", file: GoVersionFile, content: "\n", wantErr: true},
+		{name: "asdf的.tool-versions文件", file: ToolVersionsFile, content: "nodejs 20.10.0\ngolang 1.22.3 1.21.5 # comment\n", expected: "1.22.3"},
+		{name: "mise的.tool-versions文件", file: ToolVersionsFile, content: "go 1.21.5\n", expected: "1.21.5"},
+		{name: "不含go的.tool-versions文件", file: ToolVersionsFile, content: "nodejs 20.10.0\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), tt.file)
+			assert.Nil(t, os.WriteFile(filename, []byte(tt.content), 0600))
+
+			sel, err := ParseVersionFile(filename)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, errs.ErrGoDirectiveNotFound)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, &Selection{File: filename, Version: tt.expected}, sel)
+		})
+	}
+}
+
+func TestSelect(t *testing.T) {
+	root := t.TempDir()
+	sub := filepath.Join(root, "a", "b")
+	assert.Nil(t, os.MkdirAll(sub, 0755))
+	assert.Nil(t, os.WriteFile(filepath.Join(sub, GoModFile), []byte("module x\n\ngo 1.21.3\n"), 0600))
+	assert.Nil(t, os.WriteFile(filepath.Join(root, "a", ToolVersionsFile), []byte("nodejs 20.10.0\n"), 0600))
+	t.Setenv(goworkEnv, "off")
+
+	t.Run("没有版本文件时使用go.mod", func(t *testing.T) {
+		sel, err := Select(sub)
+		assert.Nil(t, err)
+		assert.Equal(t, GoDirective, sel.Directive)
+	})
+
+	t.Run("子目录的go.mod优先于父目录的版本文件", func(t *testing.T) {
+		assert.Nil(t, os.WriteFile(filepath.Join(root, GoVersionFile), []byte("1.22.3\n"), 0600))
+		sel, err := Select(sub)
+		assert.Nil(t, err)
+		assert.Equal(t, filepath.Join(sub, GoModFile), sel.File)
+		assert.Equal(t, GoDirective, sel.Directive)
+	})
+
+	t.Run("向上查找版本文件", func(t *testing.T) {
+		dir := filepath.Join(root, "a", "c")
+		assert.Nil(t, os.MkdirAll(dir, 0755))
+		sel, err := Select(dir)
+		assert.Nil(t, err)
+		assert.Equal(t, &Selection{File: filepath.Join(root, GoVersionFile), Version: "1.22.3"}, sel)
+	})
+
+	t.Run("同一目录下优先使用.go-version文件", func(t *testing.T) {
+		assert.Nil(t, os.WriteFile(filepath.Join(root, ToolVersionsFile), []byte("golang 1.21.5\n"), 0600))
+		sel, err := Select(filepath.Join(root, "a", "c"))
+		assert.Nil(t, err)
+		assert.Equal(t, "1.22.3", sel.Version)
+	})
+
+	t.Run("同一目录下版本文件优先于go.mod", func(t *testing.T) {
+		assert.Nil(t, os.WriteFile(filepath.Join(sub, GoVersionFile), []byte("1.21.5\n"), 0600))
+		defer os.Remove(filepath.Join(sub, GoVersionFile))
+		sel, err := Select(sub)
+		assert.Nil(t, err)
+		assert.Equal(t, &Selection{File: filepath.Join(sub, GoVersionFile), Version: "1.21.5"}, sel)
+	})
+
+	t.Run("go.work优先于父目录的版本文件", func(t *testing.T) {
+		t.Setenv(goworkEnv, "")
+		assert.Nil(t, os.WriteFile(filepath.Join(root, "a", GoWorkFile), []byte("go 1.22.1\n\nuse ./b\n"), 0600))
+		defer os.Remove(filepath.Join(root, "a", GoWorkFile))
+
+		sel, err := Select(sub)
+		assert.Nil(t, err)
+		assert.Equal(t, filepath.Join(root, "a", GoWorkFile), sel.File)
+
+		sel, err = Select(filepath.Join(root, "a", "c"))
+		assert.Nil(t, err)
+		assert.Equal(t, filepath.Join(root, "a", GoWorkFile), sel.File)
+	})
+
+	t.Run("未找到任何项目文件", func(t *testing.T) {
+		_, err := Select(t.TempDir())
+		assert.ErrorIs(t, err, errs.ErrProjectFileNotFound)
+	})
+}
+
+func TestWriteVersionFile(t *testing.T) {
+	t.Run("写入.go-version文件", func(t *testing.T) {
+		dir := t.TempDir()
+		filename, err := WriteVersionFile(dir, "1.22.3")
+		assert.Nil(t, err)
+		assert.Equal(t, filepath.Join(dir, GoVersionFile), filename)
+		data, _ := os.ReadFile(filename)
+		assert.Equal(t, "1.22.3\n", string(data))
+	})
+
+	t.Run("更新已有的.tool-versions文件", func(t *testing.T) {
+		dir := t.TempDir()
+		assert.Nil(t, os.WriteFile(filepath.Join(dir, ToolVersionsFile), []byte("nodejs 20.10.0\ngolang 1.21.5\n"), 0600))
+		filename, err := WriteVersionFile(dir, "1.22.3")
+		assert.Nil(t, err)
+		assert.Equal(t, filepath.Join(dir, ToolVersionsFile), filename)
+		data, _ := os.ReadFile(filename)
+		assert.Equal(t, "nodejs 20.10.0\ngolang 1.22.3\n", string(data))
+	})
+
+	t.Run("保留.tool-versions文件中的注释", func(t *testing.T) {
+		dir := t.TempDir()
+		assert.Nil(t, os.WriteFile(filepath.Join(dir, ToolVersionsFile), []byte("golang  1.21.5 # pinned for CI\n"), 0600))
+		filename, err := WriteVersionFile(dir, "1.22.3")
+		assert.Nil(t, err)
+		data, _ := os.ReadFile(filename)
+		assert.Equal(t, "golang  1.22.3 # pinned for CI\n", string(data))
+	})
+
+	t.Run("同时存在.go-version和.tool-versions文件", func(t *testing.T) {
+		dir := t.TempDir()
+		assert.Nil(t, os.WriteFile(filepath.Join(dir, GoVersionFile), []byte("# pinned\n1.21.0\n"), 0600))
+		assert.Nil(t, os.WriteFile(filepath.Join(dir, ToolVersionsFile), []byte("nodejs 20.0.0\n"), 0600))
+		filename, err := WriteVersionFile(dir, "1.22.3")
+		assert.Nil(t, err)
+		assert.Equal(t, filepath.Join(dir, GoVersionFile), filename)
+		data, _ := os.ReadFile(filename)
+		assert.Equal(t, "# pinned\n1.22.3\n", string(data))
+		data, _ = os.ReadFile(filepath.Join(dir, ToolVersionsFile))
+		assert.Equal(t, "nodejs 20.0.0\n", string(data))
+
+		sel, err := Select(dir)
+		assert.Nil(t, err)
+		assert.Equal(t, "1.22.3", sel.Version)
+	})
+
+	t.Run("向.tool-versions文件追加go", func(t *testing.T) {
+		dir := t.TempDir()
+		assert.Nil(t, os.WriteFile(filepath.Join(dir, ToolVersionsFile), []byte("nodejs 20.10.0\n"), 0600))
+		filename, err := WriteVersionFile(dir, "1.22.3")
+		assert.Nil(t, err)
+		data, _ := os.ReadFile(filename)
+		assert.Equal(t, "nodejs 20.10.0\ngolang 1.22.3\n", string(data))
+	})
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package signature
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/voidint/g/pkg/errs"
+)
+
+func newEntity(t *testing.T) *openpgp.Entity {
+	e, err := openpgp.NewEntity("g", "test", "g@example.com", nil)
+	assert.Nil(t, err)
+	return e
+}
+
+func writeKeyring(t *testing.T, dir string, e *openpgp.Entity, armored bool) string {
+	var buf bytes.Buffer
+	if armored {
+		w, err := armor.Encode(&buf, openpgp.PublicKeyType, nil)
+		assert.Nil(t, err)
+		assert.Nil(t, e.Serialize(w))
+		assert.Nil(t, w.Close())
+	} else {
+		assert.Nil(t, e.Serialize(&buf))
+	}
+	filename := filepath.Join(dir, "keyring.gpg")
+	assert.Nil(t, os.WriteFile(filename, buf.Bytes(), 0644))
+	return filename
+}
+
+func TestNewVerifier(t *testing.T) {
+	t.Run("内嵌的Go发布签名公钥", func(t *testing.T) {
+		v, err := NewVerifier("")
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(v.keyring))
+		assert.Equal(t, GoReleaseKeyFingerprint, fmt.Sprintf("%X", v.keyring[0].PrimaryKey.Fingerprint))
+	})
+
+	t.Run("自定义密钥环", func(t *testing.T) {
+		dir := t.TempDir()
+		e := newEntity(t)
+		for _, armored := range []bool{true, false} {
+			v, err := NewVerifier(writeKeyring(t, dir, e, armored))
+			assert.Nil(t, err)
+			assert.Equal(t, 1, len(v.keyring))
+		}
+	})
+
+	t.Run("密钥环文件不存在", func(t *testing.T) {
+		_, err := NewVerifier(filepath.Join(t.TempDir(), "404.gpg"))
+		assert.True(t, os.IsNotExist(errors.Cause(err)))
+	})
+
+	t.Run("无效的密钥环", func(t *testing.T) {
+		filename := filepath.Join(t.TempDir(), "keyring.gpg")
+		assert.Nil(t, os.WriteFile(filename, []byte("hello world"), 0644))
+		_, err := NewVerifier(filename)
+		assert.True(t, errs.IsInvalidKeyring(err))
+	})
+}
+
+func TestVerifier_VerifyFile(t *testing.T) {
+	dir := t.TempDir()
+	signer := newEntity(t)
+	stranger := newEntity(t)
+
+	filename := filepath.Join(dir, "go1.21.4.linux-amd64.tar.gz")
+	assert.Nil(t, os.WriteFile(filename, []byte("hello world"), 0644))
+
+	sign := func(e *openpgp.Entity, armored bool, content string) string {
+		var buf bytes.Buffer
+		if armored {
+			assert.Nil(t, openpgp.ArmoredDetachSign(&buf, e, strings.NewReader(content), nil))
+		} else {
+			assert.Nil(t, openpgp.DetachSign(&buf, e, strings.NewReader(content), nil))
+		}
+		sigFilename := filepath.Join(dir, "sig.asc")
+		assert.Nil(t, os.WriteFile(sigFilename, buf.Bytes(), 0644))
+		return sigFilename
+	}
+
+	v, err := NewVerifier(writeKeyring(t, dir, signer, true))
+	assert.Nil(t, err)
+
+	t.Run("ASCII armored签名", func(t *testing.T) {
+		assert.Nil(t, v.VerifyFile(filename, sign(signer, true, "hello world")))
+	})
+
+	t.Run("二进制签名", func(t *testing.T) {
+		assert.Nil(t, v.VerifyFile(filename, sign(signer, false, "hello world")))
+	})
+
+	t.Run("文件被篡改", func(t *testing.T) {
+		assert.Equal(t, errs.ErrSignatureNotMatched, v.VerifyFile(filename, sign(signer, true, "hello 世界")))
+	})
+
+	t.Run("未知的签名者", func(t *testing.T) {
+		assert.Equal(t, errs.ErrUnknownSigner, v.VerifyFile(filename, sign(stranger, true, "hello world")))
+	})
+
+	t.Run("签名时有效但现已过期的密钥", func(t *testing.T) {
+		created := time.Now().Add(-48 * time.Hour)
+		config := packet.Config{Time: func() time.Time { return created }, KeyLifetimeSecs: 3600}
+		e, err := openpgp.NewEntity("g", "test", "g@example.com", &config)
+		assert.Nil(t, err)
+		v, err := NewVerifier(writeKeyring(t, dir, e, true))
+		assert.Nil(t, err)
+
+		var buf bytes.Buffer
+		config.Time = func() time.Time { return created.Add(time.Minute) }
+		assert.Nil(t, openpgp.ArmoredDetachSign(&buf, e, strings.NewReader("hello world"), &config))
+		assert.Nil(t, v.Verify(strings.NewReader("hello world"), buf.Bytes()))
+	})
+
+	t.Run("签名文件无效", func(t *testing.T) {
+		sigFilename := filepath.Join(dir, "invalid.asc")
+		assert.Nil(t, os.WriteFile(sigFilename, []byte("hello world"), 0644))
+		assert.Equal(t, errs.ErrSignatureNotMatched, v.VerifyFile(filename, sigFilename))
+	})
+}
@@ -0,0 +1,369 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// Package vulndb matches Go versions against the standard library vulnerabilities of a database in the OSV format, such as the Go vulnerability database.
+package vulndb
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/voidint/g/pkg/errs"
+	httppkg "github.com/voidint/g/pkg/http"
+	"golang.org/x/mod/semver"
+)
+
+const (
+	// DefaultURL is the Go vulnerability database used when GOVULNDB is not set.
+	DefaultURL = "https://vuln.go.dev"
+	// StdlibModule is the pseudo module path of the Go standard library in the Go vulnerability database.
+	StdlibModule = "stdlib"
+	// ToolchainModule is the pseudo module path of the go command in the Go vulnerability database.
+	ToolchainModule = "toolchain"
+)
+
+// concurrency is the maximum number of entries downloaded at the same time.
+const concurrency = 8
+
+// Entry is a vulnerability entry in the OSV format.
+type Entry struct {
+	ID       string     `json:"id"`
+	Aliases  []string   `json:"aliases,omitempty"`
+	Summary  string     `json:"summary,omitempty"`
+	Details  string     `json:"details,omitempty"`
+	Affected []Affected `json:"affected,omitempty"`
+}
+
+// Affected describes the versions of a module affected by the vulnerability.
+type Affected struct {
+	Package struct {
+		Name      string `json:"name"`
+		Ecosystem string `json:"ecosystem"`
+	} `json:"package"`
+	Ranges            []Range `json:"ranges,omitempty"`
+	EcosystemSpecific struct {
+		Imports []struct {
+			Path string `json:"path"`
+		} `json:"imports,omitempty"`
+	} `json:"ecosystem_specific"`
+}
+
+// Range is a list of events marking the versions where the vulnerability is introduced and fixed.
+type Range struct {
+	Type   string  `json:"type"`
+	Events []Event `json:"events"`
+}
+
+// Event is either an 'introduced' or a 'fixed' event. The 'introduced' version '0' stands for all versions.
+type Event struct {
+	Introduced string `json:"introduced,omitempty"`
+	Fixed      string `json:"fixed,omitempty"`
+}
+
+// Finding is a vulnerability affecting a Go version.
+type Finding struct {
+	ID       string   `json:"id"`
+	Aliases  []string `json:"aliases,omitempty"`
+	Summary  string   `json:"summary,omitempty"`
+	Module   string   `json:"module"`
+	Packages []string `json:"packages,omitempty"`
+	// Fixed is the version fixing the vulnerability, which is empty if there is no fix yet.
+	Fixed string `json:"fixed,omitempty"`
+}
+
+// DB holds the entries affecting the Go standard library or toolchain.
+type DB struct {
+	entries []*Entry
+}
+
+// Load reads the database from the URL (e.g. https://vuln.go.dev), or from the local path,
+// which is either a copy of the database, a directory of OSV entry files or a single file.
+func Load(src string) (*DB, error) {
+	if strings.HasPrefix(src, "http://") || strings.HasPrefix(src, "https://") {
+		return loadURL(strings.TrimSuffix(src, "/"))
+	}
+	return loadPath(strings.TrimPrefix(src, "file://"))
+}
+
+// Len returns the number of entries affecting the Go standard library or toolchain.
+func (db *DB) Len() int {
+	return len(db.entries)
+}
+
+// loadURL downloads the entries of the standard library and toolchain modules listed by the module index.
+func loadURL(baseURL string) (*DB, error) {
+	data, err := httppkg.DownloadAsBytes(baseURL + "/index/modules.json")
+	if err != nil {
+		return nil, err
+	}
+	var modules []struct {
+		Path  string `json:"path"`
+		Vulns []struct {
+			ID string `json:"id"`
+		} `json:"vulns"`
+	}
+	if err = json.Unmarshal(data, &modules); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	ids := make(map[string]bool)
+	for _, m := range modules {
+		if !isGoModule(m.Path) {
+			continue
+		}
+		for _, v := range m.Vulns {
+			ids[v.ID] = true
+		}
+	}
+
+	var (
+		db       DB
+		mux      sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+		sem      = make(chan struct{}, concurrency)
+	)
+	for id := range ids {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(id string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			var entry Entry
+			data, err := httppkg.DownloadAsBytes(fmt.Sprintf("%s/ID/%s.json", baseURL, id))
+			if err == nil {
+				err = errors.WithStack(json.Unmarshal(data, &entry))
+			}
+			mux.Lock()
+			defer mux.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			db.add(&entry)
+		}(id)
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	db.sort()
+	return &db, nil
+}
+
+// loadPath reads the OSV entry files in the directory (the index files are skipped) or the single file,
+// which holds either an entry or an array of entries.
+func loadPath(path string) (*DB, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var db DB
+	if !fi.IsDir() {
+		if err = db.addFile(path); err != nil {
+			return nil, err
+		}
+		db.sort()
+		return &db, nil
+	}
+
+	err = filepath.WalkDir(path, func(filename string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == "index" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(filename) != ".json" {
+			return nil
+		}
+		return db.addFile(filename)
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	db.sort()
+	return &db, nil
+}
+
+func (db *DB) addFile(filename string) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	var entries []*Entry
+	if trimmed := strings.TrimSpace(string(data)); strings.HasPrefix(trimmed, "[") {
+		err = json.Unmarshal(data, &entries)
+	} else {
+		var entry Entry
+		err = json.Unmarshal(data, &entry)
+		entries = append(entries, &entry)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "malformed OSV file %q", filename)
+	}
+	for _, entry := range entries {
+		db.add(entry)
+	}
+	return nil
+}
+
+// add keeps the entry if it affects the Go standard library or toolchain.
+func (db *DB) add(entry *Entry) {
+	if entry.ID == "" {
+		return
+	}
+	for _, a := range entry.Affected {
+		if isGoModule(a.Package.Name) {
+			db.entries = append(db.entries, entry)
+			return
+		}
+	}
+}
+
+func (db *DB) sort() {
+	sort.Slice(db.entries, func(i, j int) bool {
+		return db.entries[i].ID < db.entries[j].ID
+	})
+}
+
+func isGoModule(path string) bool {
+	return path == StdlibModule || path == ToolchainModule
+}
+
+// Check returns the vulnerabilities affecting the Go version, ordered by ID.
+func (db *DB) Check(goVersion string) ([]Finding, error) {
+	v, err := Canonical(goVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	findings := make([]Finding, 0)
+	for _, entry := range db.entries {
+		var (
+			finding *Finding
+			seen    = make(map[string]bool)
+		)
+		for _, a := range entry.Affected {
+			if !isGoModule(a.Package.Name) {
+				continue
+			}
+			affected, fixed := affects(a.Ranges, v)
+			if !affected {
+				continue
+			}
+			if finding == nil {
+				finding = &Finding{
+					ID:      entry.ID,
+					Aliases: entry.Aliases,
+					Summary: entry.Summary,
+					Module:  a.Package.Name,
+					Fixed:   fixed,
+				}
+			}
+			for _, imp := range a.EcosystemSpecific.Imports {
+				if !seen[imp.Path] {
+					seen[imp.Path] = true
+					finding.Packages = append(finding.Packages, imp.Path)
+				}
+			}
+		}
+		if finding != nil {
+			findings = append(findings, *finding)
+		}
+	}
+	return findings, nil
+}
+
+// affects reports whether the canonical version falls into one of the SEMVER ranges, and which version fixes it.
+func affects(ranges []Range, v string) (affected bool, fixed string) {
+	for _, r := range ranges {
+		if r.Type != "SEMVER" {
+			continue
+		}
+		events := make([]Event, len(r.Events))
+		copy(events, r.Events)
+		sort.SliceStable(events, func(i, j int) bool {
+			return semver.Compare(eventVersion(events[i]), eventVersion(events[j])) < 0
+		})
+
+		var in bool
+		for _, e := range events {
+			if semver.Compare(eventVersion(e), v) <= 0 {
+				in = e.Introduced != ""
+				continue
+			}
+			if in && e.Fixed != "" {
+				return true, e.Fixed
+			}
+			break
+		}
+		if in {
+			return true, ""
+		}
+	}
+	return false, ""
+}
+
+// eventVersion returns the canonical version of the event. The 'introduced' version '0' precedes all versions.
+func eventVersion(e Event) string {
+	if e.Introduced != "" {
+		if e.Introduced == "0" {
+			return "v0.0.0-0"
+		}
+		return "v" + e.Introduced
+	}
+	return "v" + e.Fixed
+}
+
+var goVersionReg = regexp.MustCompile(`^(?:go)?(\d+)\.(\d+)(?:\.(\d+))?(?:(alpha|beta|rc)(\d+))?$`)
+
+// Canonical converts the Go version to the semantic version used by the Go vulnerability database,
+// e.g. '1.21.4' => 'v1.21.4', '1.20' => 'v1.20.0', '1.21rc2' => 'v1.21.0-rc.2'.
+func Canonical(goVersion string) (string, error) {
+	matches := goVersionReg.FindStringSubmatch(goVersion)
+	if matches == nil {
+		return "", errs.NewMalformedVersionError(goVersion, errors.New("not a Go release version"))
+	}
+	patch := matches[3]
+	if patch == "" {
+		patch = "0"
+	}
+	v := fmt.Sprintf("v%s.%s.%s", matches[1], matches[2], patch)
+	if matches[4] != "" {
+		v += fmt.Sprintf("-%s.%s", matches[4], matches[5])
+	}
+	return v, nil
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package vulndb
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/voidint/g/pkg/errs"
+)
+
+func TestCanonical(t *testing.T) {
+	tests := []struct {
+		in       string
+		expected string
+		wantErr  bool
+	}{
+		{in: "1.21.4", expected: "v1.21.4"},
+		{in: "go1.21.4", expected: "v1.21.4"},
+		{in: "1.20", expected: "v1.20.0"},
+		{in: "1.21rc2", expected: "v1.21.0-rc.2"},
+		{in: "1.9beta1", expected: "v1.9.0-beta.1"},
+		{in: "1", wantErr: true},
+		{in: "abc", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := Canonical(tt.in)
+			if tt.wantErr {
+				assert.True(t, errs.IsMalformedVersion(err))
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestDB_Check(t *testing.T) {
+	db, err := Load("testdata")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, db.Len()) // entries of other modules are dropped
+
+	tests := []struct {
+		name    string
+		version string
+		ids     []string
+		fixed   []string
+	}{
+		{name: "受两个漏洞影响的旧版本", version: "1.19.5", ids: []string{"GO-2023-1987", "GO-2023-2185"}, fixed: []string{"1.19.12", "1.20.11"}},
+		{name: "修复版本不受影响", version: "1.20.7", ids: []string{"GO-2023-2185"}, fixed: []string{"1.20.11"}},
+		{name: "预发布版本", version: "1.21rc3", ids: []string{"GO-2023-1987", "GO-2023-2185"}, fixed: []string{"1.21.0-rc.4", "1.21.4"}},
+		{name: "无已知漏洞", version: "1.21.4"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings, err := db.Check(tt.version)
+			assert.Nil(t, err)
+			assert.Equal(t, len(tt.ids), len(findings))
+			for i := range findings {
+				assert.Equal(t, tt.ids[i], findings[i].ID)
+				assert.Equal(t, tt.fixed[i], findings[i].Fixed)
+				assert.Equal(t, StdlibModule, findings[i].Module)
+			}
+		})
+	}
+
+	findings, err := db.Check("1.21.3")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(findings))
+	assert.Equal(t, []string{"CVE-2023-45284"}, findings[0].Aliases)
+	assert.Equal(t, []string{"path/filepath", "internal/safefilepath"}, findings[0].Packages)
+
+	_, err = db.Check("abc")
+	assert.NotNil(t, err)
+}
+
+func Test_affects(t *testing.T) {
+	ranges := []Range{
+		{Type: "ECOSYSTEM", Events: []Event{{Introduced: "0"}}},
+		{Type: "SEMVER", Events: []Event{{Introduced: "1.21.0-0"}}},
+	}
+
+	affected, fixed := affects(ranges, "v1.20.14")
+	assert.False(t, affected)
+	assert.Equal(t, "", fixed)
+
+	affected, fixed = affects(ranges, "v1.22.0")
+	assert.True(t, affected)
+	assert.Equal(t, "", fixed) // not fixed yet
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("单个文件", func(t *testing.T) {
+		db, err := Load(filepath.Join("testdata", "ID", "GO-2023-2185.json"))
+		assert.Nil(t, err)
+		assert.Equal(t, 1, db.Len())
+	})
+
+	t.Run("包含数组的文件", func(t *testing.T) {
+		filename := filepath.Join(t.TempDir(), "osv.json")
+		assert.Nil(t, os.WriteFile(filename, []byte(`[{"id": "GO-0000-0001", "affected": [{"package": {"name": "toolchain"}}]}, {"id": "GO-0000-0002"}]`), 0600))
+		db, err := Load("file://" + filename)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, db.Len())
+	})
+
+	t.Run("格式错误的文件", func(t *testing.T) {
+		filename := filepath.Join(t.TempDir(), "osv.json")
+		assert.Nil(t, os.WriteFile(filename, []byte(`{`), 0600))
+		_, err := Load(filename)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("路径不存在", func(t *testing.T) {
+		_, err := Load(filepath.Join(t.TempDir(), "nonexistent"))
+		assert.NotNil(t, err)
+	})
+
+	t.Run("远程数据库", func(t *testing.T) {
+		srv := httptest.NewServer(http.FileServer(http.Dir("testdata")))
+		defer srv.Close()
+
+		db, err := Load(srv.URL + "/")
+		assert.Nil(t, err)
+		assert.Equal(t, 2, db.Len())
+		assert.Equal(t, "GO-2023-1987", db.entries[0].ID)
+	})
+
+	t.Run("远程数据库不可访问", func(t *testing.T) {
+		srv := httptest.NewServer(http.NotFoundHandler())
+		defer srv.Close()
+
+		_, err := Load(srv.URL)
+		assert.True(t, errs.IsURLUnreachable(err))
+	})
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package version
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// Constraint is a version constraint shared by the lookup of versions and the filtering of version lists.
+//
+//	Supported syntax:
+//	1. The constraints of semantic versions (e.g. '~1.21', '^1.20', '>=1.21', '1.18 - 1.20', '1.21.x')
+//	2. Go version names as operands, with or without the 'go' prefix (e.g. '>=go1.21.4', '!=1.22rc1')
+//	3. Exclusions (e.g. '>=1.21, !=1.21.2')
+//	4. Alternatives (e.g. '1.20.x || 1.22.x')
+//
+// Release candidates and betas only satisfy the constraints naming a prerelease, unless prereleases are included.
+type Constraint struct {
+	expr       string
+	cs         *semver.Constraints
+	prerelease bool
+}
+
+// WithPrerelease includes the release candidates and betas, which satisfy the constraint if the release they precede does,
+// e.g. '1.22rc1' satisfies '>=1.22' and '1.22.x'.
+func WithPrerelease() func(c *Constraint) {
+	return func(c *Constraint) {
+		c.prerelease = true
+	}
+}
+
+var (
+	goPrefixReg     = regexp.MustCompile(`(^|[^0-9A-Za-z])go(\d)`)
+	goPrereleaseReg = regexp.MustCompile(`(\d+\.\d+)(\.\d+)?(alpha|beta|rc)(\d+)`)
+)
+
+// NewConstraint parses the version constraint.
+func NewConstraint(expr string, opts ...func(c *Constraint)) (*Constraint, error) {
+	cs, err := semver.NewConstraint(normalizeConstraint(expr))
+	if err != nil {
+		return nil, err
+	}
+
+	c := Constraint{
+		expr: expr,
+		cs:   cs,
+	}
+	for _, setter := range opts {
+		if setter != nil {
+			setter(&c)
+		}
+	}
+	return &c, nil
+}
+
+// normalizeConstraint rewrites the Go version names in the constraint into semantic versions, e.g. 'go1.22rc1' into '1.22.0-rc1'.
+func normalizeConstraint(expr string) string {
+	expr = goPrefixReg.ReplaceAllString(strings.TrimSpace(expr), "${1}${2}")
+	return goPrereleaseReg.ReplaceAllStringFunc(expr, func(s string) string {
+		sm := goPrereleaseReg.FindStringSubmatch(s)
+		patch := sm[2]
+		if patch == "" {
+			patch = ".0"
+		}
+		return sm[1] + patch + "-" + sm[3] + sm[4]
+	})
+}
+
+// String returns the constraint as given.
+func (c *Constraint) String() string {
+	return c.expr
+}
+
+// Check reports whether the version satisfies the constraint.
+func (c *Constraint) Check(v *Version) bool {
+	if c.cs.Check(v.sv) {
+		return true
+	}
+	if !c.prerelease || v.sv.Prerelease() == "" {
+		return false
+	}
+	release, err := v.sv.SetPrerelease("")
+	if err != nil {
+		return false
+	}
+	return c.cs.Check(&release)
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConstraint_Check(t *testing.T) {
+	tests := []struct {
+		name       string
+		expr       string
+		prerelease bool
+		vname      string
+		want       bool
+	}{
+		{name: "go前缀", expr: "go1.21.4", vname: "1.21.4", want: true},
+		{name: "比较运算符后的go前缀", expr: ">=go1.21", vname: "1.21.4", want: true},
+		{name: "或约束", expr: "1.20.x || 1.22.x", vname: "1.22.1", want: true},
+		{name: "或约束不匹配", expr: "1.20.x || 1.22.x", vname: "1.21.4", want: false},
+		{name: "排除版本", expr: ">=1.21, !=1.21.2", vname: "1.21.2", want: false},
+		{name: "排除版本外的版本", expr: ">=1.21, !=1.21.2", vname: "1.21.3", want: true},
+		{name: "默认不匹配预发布版", expr: ">=1.21", vname: "1.22rc1", want: false},
+		{name: "包含预发布版", expr: ">=1.22", prerelease: true, vname: "1.22rc1", want: true},
+		{name: "包含预发布版的通配符", expr: "1.22.x", prerelease: true, vname: "1.22rc1", want: true},
+		{name: "包含预发布版但不满足约束", expr: "~1.21", prerelease: true, vname: "1.22rc1", want: false},
+		{name: "约束中的预发布版", expr: "!=go1.22rc1, >=1.22rc1", vname: "1.22rc2", want: true},
+		{name: "排除预发布版", expr: "!=go1.22rc1, >=1.22rc1", vname: "1.22rc1", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts []func(c *Constraint)
+			if tt.prerelease {
+				opts = append(opts, WithPrerelease())
+			}
+			c, err := NewConstraint(tt.expr, opts...)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expr, c.String())
+			assert.Equal(t, tt.want, c.Check(MustNew(tt.vname)))
+		})
+	}
+
+	t.Run("非法约束", func(t *testing.T) {
+		_, err := NewConstraint("voidint")
+		assert.NotNil(t, err)
+	})
+}
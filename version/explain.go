@@ -0,0 +1,72 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package version
+
+// Results of the candidate versions considered by Finder.
+const (
+	// CandidateSelected is the result of the version chosen.
+	CandidateSelected = "selected"
+	// CandidateRejected is the result of the versions not matching the version name.
+	CandidateRejected = "rejected"
+	// CandidateNoPackage is the result of the versions matching the version name without a package for the target platform.
+	CandidateNoPackage = "no-package"
+)
+
+// Candidate is a version considered by Finder and the decision on it.
+type Candidate struct {
+	Version string `json:"version"`
+	Result  string `json:"result"`
+	Reason  string `json:"reason"`
+}
+
+// Explanation records how Finder resolved a version name, in the order the candidate versions were considered.
+type Explanation struct {
+	Query      string      `json:"query"`
+	Kind       string      `json:"kind,omitempty"`
+	Goos       string      `json:"goos,omitempty"`
+	Goarch     string      `json:"goarch,omitempty"`
+	Candidates []Candidate `json:"candidates"`
+	Selected   string      `json:"selected,omitempty"`
+	Error      string      `json:"error,omitempty"`
+	// Suggestions are the available versions close to the query if no version was found.
+	Suggestions []string `json:"suggestions,omitempty"`
+	// Platforms are the platforms the version found has a package for if it has none for the target platform.
+	Platforms []string `json:"platforms,omitempty"`
+	// Mirror is the mirror site supplying the versions, set by the callers looking up remote versions.
+	Mirror string `json:"mirror,omitempty"`
+	// URL is the download URL of the package of the selected version, set by the callers looking up remote versions.
+	URL string `json:"url,omitempty"`
+}
+
+// record appends the decision on the candidate version. It does nothing on a nil explanation.
+func (ex *Explanation) record(v *Version, result, reason string) {
+	if ex == nil {
+		return
+	}
+	ex.Candidates = append(ex.Candidates, Candidate{Version: v.name, Result: result, Reason: reason})
+}
+
+// reset drops the candidates recorded. It does nothing on a nil explanation.
+func (ex *Explanation) reset() {
+	if ex == nil {
+		return
+	}
+	ex.Candidates = ex.Candidates[:0]
+}
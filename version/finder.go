@@ -20,19 +20,25 @@
 package version
 
 import (
+	"fmt"
+	"regexp"
 	"runtime"
 	"sort"
+	"strings"
 
-	"github.com/Masterminds/semver/v3"
 	"github.com/voidint/g/pkg/errs"
 )
 
 // Finder implements version lookup for Go language distributions.
 type Finder struct {
-	kind   PackageKind
-	goos   string
-	goarch string
-	items  []*Version
+	kind       PackageKind
+	goos       string
+	goarch     string
+	anyPackage bool
+	prerelease bool
+	minGo      string
+	items      []*Version
+	ex         *Explanation
 }
 
 // WithFinderPackageKind sets the package kind to search for.
@@ -56,6 +62,27 @@ func WithFinderGoarch(goarch string) func(fdr *Finder) {
 	}
 }
 
+// WithFinderInstalled matches versions regardless of their packages, e.g. the versions installed locally.
+func WithFinderInstalled() func(fdr *Finder) {
+	return func(fdr *Finder) {
+		fdr.anyPackage = true
+	}
+}
+
+// WithFinderPrerelease lets the version constraints and minor lines match release candidates and betas too.
+func WithFinderPrerelease() func(fdr *Finder) {
+	return func(fdr *Finder) {
+		fdr.prerelease = true
+	}
+}
+
+// WithFinderMinGo sets the minimum version required by the go.mod file, which 'min-go' resolves against.
+func WithFinderMinGo(minVersion string) func(fdr *Finder) {
+	return func(fdr *Finder) {
+		fdr.minGo = minVersion
+	}
+}
+
 // NewFinder creates a new Finder instance with sorted versions and applied options.
 func NewFinder(items []*Version, opts ...func(fdr *Finder)) *Finder {
 	sort.Sort(Collection(items)) // Sort in ascending order.
@@ -87,36 +114,85 @@ func NewFinder(items []*Version, opts ...func(fdr *Finder)) *Finder {
 //	6. Greater than comparisons (e.g. '>1.18')
 //	7. Less than comparisons (e.g. '<1.16')
 //	8. Version ranges (e.g. '1.18-1.20')
+//	9. Newest stable version identifier 'stable'
+//	10. Latest patch of the previous minor line identifier 'oldstable'
+//	11. Newest release candidate or beta identifier 'next'
+//	12. Latest patch of a minor line (e.g. '1.21')
+//	13. Lowest version satisfying the go.mod file identifier 'min-go'
+//	14. Names with the 'go' prefix (e.g. 'go1.21.4')
+//	15. Exclusions (e.g. '>=1.21, !=1.21.2')
+//	16. Alternatives (e.g. '1.20.x || 1.22.x')
 func (fdr *Finder) Find(vname string) (*Version, error) {
-	if vname == Latest {
+	if goNameReg.MatchString(vname) {
+		vname = strings.TrimPrefix(vname, "go")
+	}
+
+	switch vname {
+	case Latest:
 		return fdr.findLatest()
+	case Stable:
+		return fdr.findStable()
+	case OldStable:
+		return fdr.findOldStable()
+	case Next:
+		return fdr.findNext()
+	case MinGo:
+		return fdr.findMinGo()
+	}
+
+	if lineReg.MatchString(vname) {
+		rule := fmt.Sprintf("minor line %q (stable releases only)", vname)
+		if fdr.prerelease {
+			rule = fmt.Sprintf("minor line %q", vname)
+		}
+		if v, err := fdr.findHighest(vname, rule, func(v *Version) bool {
+			return (fdr.prerelease || !IsPrerelease(v.name)) && Lang(v.name) == vname
+		}); !errs.IsVersionNotFound(err) {
+			return v, err
+		}
+		fdr.ex.reset()
 	}
 
 	for i := len(fdr.items) - 1; i >= 0; i-- {
-		if fdr.items[i].name == vname && fdr.items[i].match(fdr.goos, fdr.goarch) {
+		if fdr.items[i].name == vname && fdr.match(fdr.items[i]) {
+			fdr.ex.record(fdr.items[i], CandidateSelected, "exact version name")
 			return fdr.items[i], nil
 		}
 	}
 
-	cs, err := semver.NewConstraint(vname)
+	var opts []func(c *Constraint)
+	if fdr.prerelease {
+		opts = append(opts, WithPrerelease())
+	}
+	c, err := NewConstraint(vname, opts...)
 	if err != nil {
-		return nil, errs.NewVersionNotFoundError(vname, fdr.goos, fdr.goarch)
+		return nil, fdr.versionNotFound(vname)
 	}
+	return fdr.findHighest(vname, fmt.Sprintf("constraint %q", vname), c.Check)
+}
 
-	versionFound := false
-	for i := len(fdr.items) - 1; i >= 0; i-- { // Prefer higher versions first.
-		if cs.Check(fdr.items[i].sv) {
-			versionFound = true
+// Explain looks the version up like Find, and records the candidate versions considered with the reasons of the decisions.
+func (fdr *Finder) Explain(vname string) (*Version, *Explanation, error) {
+	ex := Explanation{Query: vname, Candidates: []Candidate{}}
+	if !fdr.anyPackage {
+		ex.Kind, ex.Goos, ex.Goarch = string(fdr.kind), fdr.goos, fdr.goarch
+	}
+	fdr.ex = &ex
+	defer func() { fdr.ex = nil }()
 
-			if fdr.items[i].match(fdr.goos, fdr.goarch) {
-				return fdr.items[i], nil
-			}
+	v, err := fdr.Find(vname)
+	if err != nil {
+		ex.Error = err.Error()
+		switch e := err.(type) {
+		case *errs.VersionNotFoundError:
+			ex.Suggestions = e.Suggestions()
+		case *errs.PackageNotFoundError:
+			ex.Platforms = e.Platforms()
 		}
+	} else {
+		ex.Selected = v.name
 	}
-	if versionFound {
-		return nil, errs.NewPackageNotFoundError(string(fdr.kind), fdr.goos, fdr.goarch)
-	}
-	return nil, errs.NewVersionNotFoundError(vname, fdr.goos, fdr.goarch)
+	return v, &ex, err
 }
 
 // MustFind returns matched version or panics on error.
@@ -128,18 +204,166 @@ func (fdr *Finder) MustFind(vname string) *Version {
 	return v
 }
 
-// Latest represents the current stable release.
-const Latest = "latest"
+const (
+	// Latest represents the newest release, release candidates and betas included.
+	Latest = "latest"
+	// Stable represents the newest stable release.
+	Stable = "stable"
+	// OldStable represents the latest patch of the minor line preceding the newest stable release.
+	OldStable = "oldstable"
+	// Next represents the newest release candidate or beta newer than the newest stable release.
+	Next = "next"
+	// MinGo represents the lowest release satisfying the go.mod file.
+	MinGo = "min-go"
+)
+
+// IsSelector reports whether the version name is one of the symbolic selectors.
+func IsSelector(vname string) bool {
+	return vname == Latest || vname == Stable || vname == OldStable || vname == Next || vname == MinGo
+}
+
+// goNameReg matches the version names with the 'go' prefix (e.g. 'go1.21.4').
+var goNameReg = regexp.MustCompile(`^go\d`)
+
+// lineReg matches the minor lines (e.g. '1.21') naming the latest patch of the line.
+var lineReg = regexp.MustCompile(`^\d+\.\d+$`)
+
+// IsMinorLine reports whether the version name is a minor line (e.g. '1.21'), which names the latest patch of the line.
+func IsMinorLine(vname string) bool {
+	return lineReg.MatchString(vname)
+}
 
 func (fdr *Finder) findLatest() (*Version, error) {
-	if len(fdr.items) == 0 {
-		return nil, errs.NewVersionNotFoundError(Latest, fdr.goos, fdr.goarch)
+	return fdr.findHighest(Latest, fmt.Sprintf("selector %q", Latest), func(*Version) bool { return true })
+}
+
+func (fdr *Finder) findStable() (*Version, error) {
+	return fdr.findHighest(Stable, fmt.Sprintf("selector %q (stable releases only)", Stable), func(v *Version) bool {
+		return !IsPrerelease(v.name)
+	})
+}
+
+func (fdr *Finder) findOldStable() (*Version, error) {
+	stable := fdr.newestStable()
+	if stable == nil {
+		return nil, errs.NewVersionNotFoundError(OldStable, fdr.goos, fdr.goarch)
 	}
+	rule := fmt.Sprintf("selector %q (stable releases before the %s line)", OldStable, Lang(stable.name))
+	return fdr.findHighest(OldStable, rule, func(v *Version) bool {
+		return !IsPrerelease(v.name) && Compare(Lang(v.name), Lang(stable.name)) < 0
+	})
+}
 
+func (fdr *Finder) findNext() (*Version, error) {
+	stable := fdr.newestStable()
+	rule := fmt.Sprintf("selector %q (prereleases)", Next)
+	if stable != nil {
+		rule = fmt.Sprintf("selector %q (prereleases newer than %s)", Next, stable.name)
+	}
+	return fdr.findHighest(Next, rule, func(v *Version) bool {
+		return IsPrerelease(v.name) && (stable == nil || Compare(v.name, stable.name) > 0)
+	})
+}
+
+func (fdr *Finder) findMinGo() (*Version, error) {
+	if fdr.minGo == "" {
+		return nil, errs.NewVersionNotFoundError(MinGo, fdr.goos, fdr.goarch)
+	}
+	rule := fmt.Sprintf("selector %q (releases not older than %s)", MinGo, fdr.minGo)
+	return fdr.findLowest(MinGo, rule, func(v *Version) bool {
+		if IsPrerelease(v.name) && !fdr.prerelease && !IsPrerelease(fdr.minGo) {
+			return false
+		}
+		return Compare(v.name, fdr.minGo) >= 0
+	})
+}
+
+// newestStable returns the newest stable version regardless of its packages.
+func (fdr *Finder) newestStable() *Version {
 	for i := len(fdr.items) - 1; i >= 0; i-- {
-		if fdr.items[i].match(fdr.goos, fdr.goarch) {
-			return fdr.items[i], nil
+		if !IsPrerelease(fdr.items[i].name) {
+			return fdr.items[i]
+		}
+	}
+	return nil
+}
+
+// findHighest returns the highest version accepted by the rule with a package for the target platform.
+// The error tells apart the versions not found from the versions without a package.
+func (fdr *Finder) findHighest(vname, rule string, accept func(v *Version) bool) (*Version, error) {
+	var found *Version
+	for i := len(fdr.items) - 1; i >= 0; i-- { // Prefer higher versions first.
+		if fdr.check(fdr.items[i], rule, accept) {
+			if found == nil {
+				found = fdr.items[i]
+			}
+
+			if fdr.match(fdr.items[i]) {
+				return fdr.items[i], nil
+			}
+		}
+	}
+	if found != nil {
+		return nil, fdr.packageNotFound(found)
+	}
+	return nil, fdr.versionNotFound(vname)
+}
+
+// findLowest returns the lowest version accepted by the rule with a package for the target platform.
+// The error tells apart the versions not found from the versions without a package.
+func (fdr *Finder) findLowest(vname, rule string, accept func(v *Version) bool) (*Version, error) {
+	var found *Version
+	for i := range fdr.items {
+		if fdr.check(fdr.items[i], rule, accept) {
+			if found == nil {
+				found = fdr.items[i]
+			}
+
+			if fdr.match(fdr.items[i]) {
+				return fdr.items[i], nil
+			}
 		}
 	}
-	return nil, errs.NewPackageNotFoundError(string(fdr.kind), fdr.goos, fdr.goarch)
+	if found != nil {
+		return nil, fdr.packageNotFound(found)
+	}
+	return nil, fdr.versionNotFound(vname)
+}
+
+// versionNotFound returns the error of the version name not found, suggesting the versions close to it.
+func (fdr *Finder) versionNotFound(vname string) error {
+	var items []*Version
+	for _, v := range fdr.items {
+		if fdr.match(v) {
+			items = append(items, v)
+		}
+	}
+	return errs.NewVersionNotFoundError(vname, fdr.goos, fdr.goarch, errs.WithSuggestions(Suggest(vname, items)...))
+}
+
+// packageNotFound returns the error of the version without a package for the target platform, listing the platforms it has a package for.
+func (fdr *Finder) packageNotFound(v *Version) error {
+	return errs.NewPackageNotFoundError(string(fdr.kind), fdr.goos, fdr.goarch,
+		errs.WithPackageVersion(v.name),
+		errs.WithPlatforms(v.platforms(fdr.kind)...),
+	)
+}
+
+// check reports whether the version is accepted by the rule, and records the decision on the candidate version.
+func (fdr *Finder) check(v *Version, rule string, accept func(v *Version) bool) bool {
+	switch {
+	case !accept(v):
+		fdr.ex.record(v, CandidateRejected, "does not match "+rule)
+		return false
+	case fdr.match(v):
+		fdr.ex.record(v, CandidateSelected, "matches "+rule)
+	default:
+		fdr.ex.record(v, CandidateNoPackage, fmt.Sprintf("matches %s, but has no %s package for %s/%s", rule, fdr.kind, fdr.goos, fdr.goarch))
+	}
+	return true
+}
+
+// match reports whether the version has a package for the target platform, unless packages do not matter.
+func (fdr *Finder) match(v *Version) bool {
+	return fdr.anyPackage || v.match(fdr.goos, fdr.goarch)
 }
@@ -315,3 +315,164 @@ func TestFinder_findLatest(t *testing.T) {
 		})
 	}
 }
+
+func TestWithFinderInstalled(t *testing.T) {
+	vs := []*Version{MustNew("1.20.14"), MustNew("1.21.4"), MustNew("1.21.5")}
+
+	_, err := NewFinder(vs).Find("1.21.4")
+	assert.NotNil(t, err)
+
+	fdr := NewFinder(vs, WithFinderInstalled())
+	v, err := fdr.Find("1.21.4")
+	assert.Nil(t, err)
+	assert.Equal(t, "1.21.4", v.Name())
+
+	v, err = fdr.Find("~1.21")
+	assert.Nil(t, err)
+	assert.Equal(t, "1.21.5", v.Name())
+
+	v, err = fdr.Find(Latest)
+	assert.Nil(t, err)
+	assert.Equal(t, "1.21.5", v.Name())
+
+	_, err = fdr.Find("1.22.0")
+	assert.True(t, errs.IsVersionNotFound(err))
+}
+
+func TestFinder_selectors(t *testing.T) {
+	vs := []*Version{
+		MustNew("1.20"), MustNew("1.20.14"), MustNew("1.21rc2"), MustNew("1.21.0"), MustNew("1.21.6"),
+		MustNew("1.22.0"), MustNew("1.22.1"), MustNew("1.23rc1"),
+	}
+	fdr := NewFinder(vs, WithFinderInstalled())
+
+	tests := []struct {
+		name  string
+		vname string
+		want  string
+	}{
+		{name: "最新版本含预发布版", vname: Latest, want: "1.23rc1"},
+		{name: "最新稳定版", vname: Stable, want: "1.22.1"},
+		{name: "上一个次版本的最新补丁版", vname: OldStable, want: "1.21.6"},
+		{name: "最新预发布版", vname: Next, want: "1.23rc1"},
+		{name: "次版本的最新补丁版", vname: "1.21", want: "1.21.6"},
+		{name: "旧版本号规则下的次版本", vname: "1.20", want: "1.20.14"},
+		{name: "精确版本号", vname: "1.21.0", want: "1.21.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := fdr.Find(tt.vname)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, v.Name())
+		})
+	}
+
+	t.Run("无更新的预发布版", func(t *testing.T) {
+		_, err := NewFinder([]*Version{MustNew("1.21rc2"), MustNew("1.21.0")}, WithFinderInstalled()).Find(Next)
+		assert.True(t, errs.IsVersionNotFound(err))
+	})
+
+	t.Run("无上一个次版本", func(t *testing.T) {
+		_, err := NewFinder([]*Version{MustNew("1.21.0"), MustNew("1.21.1")}, WithFinderInstalled()).Find(OldStable)
+		assert.True(t, errs.IsVersionNotFound(err))
+	})
+
+	t.Run("次版本无匹配的软件包", func(t *testing.T) {
+		items, err := genVersions()
+		assert.Nil(t, err)
+		_, err = NewFinder(items, WithFinderGoos("darwin"), WithFinderGoarch("arm64")).Find("1.15")
+		assert.True(t, errs.IsPackageNotFound(err))
+	})
+}
+
+func TestFinder_constraints(t *testing.T) {
+	vs := []*Version{
+		MustNew("1.20.13"), MustNew("1.20.14"), MustNew("1.21.0"), MustNew("1.21.1"), MustNew("1.21.2"),
+		MustNew("1.22rc1"), MustNew("1.22.0"), MustNew("1.23rc1"),
+	}
+
+	tests := []struct {
+		name  string
+		fdr   *Finder
+		vname string
+		want  string
+	}{
+		{name: "go前缀的版本号", fdr: NewFinder(vs, WithFinderInstalled()), vname: "go1.21.1", want: "1.21.1"},
+		{name: "或约束", fdr: NewFinder(vs, WithFinderInstalled()), vname: "1.20.x || 1.21.0", want: "1.21.0"},
+		{name: "排除版本", fdr: NewFinder(vs, WithFinderInstalled()), vname: "~1.21, !=1.21.2", want: "1.21.1"},
+		{name: "包含预发布版", fdr: NewFinder(vs, WithFinderInstalled(), WithFinderPrerelease()), vname: ">=1.22", want: "1.23rc1"},
+		{name: "包含预发布版的次版本", fdr: NewFinder(vs, WithFinderInstalled(), WithFinderPrerelease()), vname: "1.23", want: "1.23rc1"},
+		{name: "满足go.mod的最低版本", fdr: NewFinder(vs, WithFinderInstalled(), WithFinderMinGo("1.21.0")), vname: MinGo, want: "1.21.0"},
+		{name: "满足go.mod的最低版本不含预发布版", fdr: NewFinder(vs, WithFinderInstalled(), WithFinderMinGo("1.21.3")), vname: MinGo, want: "1.22.0"},
+		{name: "满足go.mod的最低预发布版", fdr: NewFinder(vs, WithFinderInstalled(), WithFinderMinGo("1.21.3"), WithFinderPrerelease()), vname: MinGo, want: "1.22rc1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := tt.fdr.Find(tt.vname)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, v.Name())
+		})
+	}
+
+	t.Run("未设置go.mod的最低版本", func(t *testing.T) {
+		_, err := NewFinder(vs, WithFinderInstalled()).Find(MinGo)
+		assert.True(t, errs.IsVersionNotFound(err))
+	})
+}
+
+func TestFinder_Explain(t *testing.T) {
+	vs := []*Version{
+		MustNew("1.20.14", WithPackages([]*Package{{FileName: "go1.20.14.linux-amd64.tar.gz", Kind: ArchiveKind}})),
+		MustNew("1.21.0", WithPackages([]*Package{{FileName: "go1.21.0.linux-amd64.tar.gz", Kind: ArchiveKind}})),
+		MustNew("1.21.1", WithPackages([]*Package{{FileName: "go1.21.1.darwin-arm64.tar.gz", Kind: ArchiveKind}})),
+		MustNew("1.22.0", WithPackages([]*Package{{FileName: "go1.22.0.linux-amd64.tar.gz", Kind: ArchiveKind}})),
+	}
+	fdr := NewFinder(vs, WithFinderGoos("linux"), WithFinderGoarch("amd64"))
+
+	t.Run("记录候选版本", func(t *testing.T) {
+		v, ex, err := fdr.Explain("~1.21")
+		assert.Nil(t, err)
+		assert.Equal(t, "1.21.0", v.Name())
+		assert.Equal(t, "~1.21", ex.Query)
+		assert.Equal(t, "linux", ex.Goos)
+		assert.Equal(t, "amd64", ex.Goarch)
+		assert.Equal(t, string(ArchiveKind), ex.Kind)
+		assert.Equal(t, "1.21.0", ex.Selected)
+		assert.Equal(t, []Candidate{
+			{Version: "1.22.0", Result: CandidateRejected, Reason: `does not match constraint "~1.21"`},
+			{Version: "1.21.1", Result: CandidateNoPackage, Reason: `matches constraint "~1.21", but has no Archive package for linux/amd64`},
+			{Version: "1.21.0", Result: CandidateSelected, Reason: `matches constraint "~1.21"`},
+		}, ex.Candidates)
+	})
+
+	t.Run("查找失败", func(t *testing.T) {
+		_, ex, err := fdr.Explain("1.21.1")
+		assert.True(t, errs.IsPackageNotFound(err))
+		assert.Equal(t, err.Error(), ex.Error)
+		assert.Equal(t, "", ex.Selected)
+	})
+
+	t.Run("精确版本号", func(t *testing.T) {
+		_, ex, err := fdr.Explain("1.20.14")
+		assert.Nil(t, err)
+		assert.Equal(t, []Candidate{{Version: "1.20.14", Result: CandidateSelected, Reason: "exact version name"}}, ex.Candidates)
+	})
+
+	t.Run("记录建议的版本", func(t *testing.T) {
+		_, ex, err := fdr.Explain("1.210")
+		assert.True(t, errs.IsVersionNotFound(err))
+		assert.Equal(t, []string{"1.21.0", "1.22.0"}, ex.Suggestions)
+		assert.Nil(t, ex.Platforms)
+	})
+
+	t.Run("记录存在软件包的平台", func(t *testing.T) {
+		_, ex, err := fdr.Explain("1.21.1")
+		assert.True(t, errs.IsPackageNotFound(err))
+		assert.Equal(t, []string{"darwin/arm64"}, ex.Platforms)
+		assert.Nil(t, ex.Suggestions)
+	})
+
+	t.Run("查找后不再记录", func(t *testing.T) {
+		assert.Nil(t, fdr.ex)
+	})
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package version
+
+import "strings"
+
+// goVersion is a Go toolchain version split into its parts, following the rules of the go command:
+// '1.21' is the language version, '1.21rc1' a release candidate and '1.21.0' the first release of Go 1.21.
+// Before Go 1.21 the first release of a minor line omitted the patch, e.g. '1.20' is the same as '1.20.0'.
+type goVersion struct {
+	major string
+	minor string
+	patch string
+	kind  string // '', 'alpha', 'beta' or 'rc'
+	pre   string
+}
+
+// Compare returns -1, 0, or +1 depending on whether x < y, x == y, or x > y, interpreted as Go versions,
+// e.g. '1.21' < '1.21rc1' < '1.21.0' < '1.21.1' and '1.20' == '1.20.0'. The 'go' prefix is optional.
+// Invalid versions compare less than valid versions and equal to each other.
+//
+// Unlike the go/version package, prereleases of patch releases published in the past (e.g. '1.9.2rc2') are accepted
+// and ordered before the patch release.
+func Compare(x, y string) int {
+	vx, vy := parseGo(x), parseGo(y)
+	if c := cmpInt(vx.major, vy.major); c != 0 {
+		return c
+	}
+	if c := cmpInt(vx.minor, vy.minor); c != 0 {
+		return c
+	}
+	if c := cmpInt(vx.patch, vy.patch); c != 0 {
+		return c
+	}
+	if vx.patch != "" && (vx.kind == "") != (vy.kind == "") {
+		// The prereleases of a patch release precede the patch release.
+		if vx.kind == "" {
+			return 1
+		}
+		return -1
+	}
+	if c := strings.Compare(vx.kind, vy.kind); c != 0 {
+		return c
+	}
+	return cmpInt(vx.pre, vy.pre)
+}
+
+// Lang returns the language version of the Go version, e.g. '1.21' for '1.21rc2' and '1.21.4'.
+// The 'go' prefix is kept if present. It returns an empty string if the version is invalid.
+func Lang(x string) string {
+	v := parseGo(x)
+	if v.major == "" {
+		return ""
+	}
+	prefix := ""
+	if strings.HasPrefix(x, "go") {
+		prefix = "go"
+	}
+	if v.minor == "" || v.major == "1" && v.minor == "0" {
+		return prefix + v.major
+	}
+	return prefix + v.major + "." + v.minor
+}
+
+// IsPrerelease reports whether the Go version is an alpha, beta or release candidate, e.g. '1.21rc1'.
+func IsPrerelease(x string) bool {
+	return parseGo(x).kind != ""
+}
+
+// IsValid reports whether the Go version is valid, e.g. '1.21', '1.21rc1' or 'go1.21.4'.
+func IsValid(x string) bool {
+	return parseGo(x).major != ""
+}
+
+// parseGo parses the Go version. The zero value is returned if the version is invalid.
+func parseGo(x string) (v goVersion) {
+	x = strings.TrimPrefix(x, "go")
+
+	var ok bool
+	if v.major, x, ok = cutInt(x); !ok {
+		return goVersion{}
+	}
+	if x == "" {
+		// '1' is the same as '1.0.0'.
+		v.minor, v.patch = "0", "0"
+		return v
+	}
+
+	if x[0] != '.' {
+		return goVersion{}
+	}
+	if v.minor, x, ok = cutInt(x[1:]); !ok {
+		return goVersion{}
+	}
+	if x == "" {
+		// The missing patch is the same as '0' before Go 1.21, and is the language version since Go 1.21.
+		if v.major == "1" && cmpInt(v.minor, "21") < 0 {
+			v.patch = "0"
+		}
+		return v
+	}
+
+	if x[0] == '.' {
+		if v.patch, x, ok = cutInt(x[1:]); !ok {
+			return goVersion{}
+		}
+		if x == "" {
+			return v
+		}
+	}
+
+	i := 0
+	for i < len(x) && (x[i] < '0' || x[i] > '9') {
+		if x[i] < 'a' || x[i] > 'z' {
+			return goVersion{}
+		}
+		i++
+	}
+	if i == 0 {
+		return goVersion{}
+	}
+	if v.kind, x = x[:i], x[i:]; x == "" {
+		return v
+	}
+	if v.pre, x, ok = cutInt(x); !ok || x != "" {
+		return goVersion{}
+	}
+	return v
+}
+
+// cutInt scans the leading decimal number without leading zeros.
+func cutInt(x string) (n, rest string, ok bool) {
+	i := 0
+	for i < len(x) && x[i] >= '0' && x[i] <= '9' {
+		i++
+	}
+	if i == 0 || x[0] == '0' && i != 1 {
+		return "", "", false
+	}
+	return x[:i], x[i:], true
+}
+
+// cmpInt compares the decimal numbers, the empty string being less than any number.
+func cmpInt(x, y string) int {
+	if x == y {
+		return 0
+	}
+	if len(x) != len(y) {
+		if len(x) < len(y) {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(x, y)
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name string
+		x    string
+		y    string
+		want int
+	}{
+		{name: "语言版本早于候选版", x: "1.21", y: "1.21rc1", want: -1},
+		{name: "候选版早于正式版", x: "1.21rc1", y: "1.21.0", want: -1},
+		{name: "测试版早于候选版", x: "1.21beta2", y: "1.21rc1", want: -1},
+		{name: "候选版序号", x: "1.21rc10", y: "1.21rc2", want: 1},
+		{name: "1.21之前省略补丁版本号", x: "1.20", y: "1.20.0", want: 0},
+		{name: "1.21之前的候选版", x: "1.20rc3", y: "1.20", want: -1},
+		{name: "补丁版本号按数值比较", x: "1.21.10", y: "1.21.9", want: 1},
+		{name: "次版本号按数值比较", x: "1.9.7", y: "1.10beta1", want: -1},
+		{name: "补丁版本的候选版", x: "1.9.2rc2", y: "1.9.2", want: -1},
+		{name: "补丁版本的候选版晚于上一补丁版本", x: "1.9.2rc2", y: "1.9.1", want: 1},
+		{name: "主版本号", x: "1", y: "1.0.0", want: 0},
+		{name: "go前缀", x: "go1.21.4", y: "1.21.4", want: 0},
+		{name: "非法版本号早于合法版本号", x: "voidint", y: "1.2.2", want: -1},
+		{name: "非法版本号相等", x: "1.21.04", y: "1.21.x", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Compare(tt.x, tt.y))
+			assert.Equal(t, -tt.want, Compare(tt.y, tt.x))
+		})
+	}
+}
+
+func TestLang(t *testing.T) {
+	for x, want := range map[string]string{
+		"1.21rc2":  "1.21",
+		"1.21.4":   "1.21",
+		"1.20":     "1.20",
+		"go1.22.1": "go1.22",
+		"1":        "1",
+		"1.0.3":    "1",
+		"voidint":  "",
+	} {
+		assert.Equal(t, want, Lang(x), x)
+	}
+}
+
+func TestIsPrerelease(t *testing.T) {
+	for x, want := range map[string]bool{
+		"1.21rc2":   true,
+		"1.4beta1":  true,
+		"1.9.2rc2":  true,
+		"1.21":      false,
+		"1.21.4":    false,
+		"go1.22rc1": true,
+		"voidint":   false,
+	} {
+		assert.Equal(t, want, IsPrerelease(x), x)
+	}
+}
+
+func TestIsValid(t *testing.T) {
+	for _, x := range []string{"1", "1.21", "1.21rc1", "1.21.4", "go1.21.4", "1.9.2rc2"} {
+		assert.True(t, IsValid(x), x)
+	}
+	for _, x := range []string{"", "go", "1.", "1.021", "1.21.x", "1.21-rc.1", "1.21RC1", "1.21rc01"} {
+		assert.False(t, IsValid(x), x)
+	}
+}
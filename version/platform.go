@@ -0,0 +1,191 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package version
+
+import (
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// osNames maps the operating system labels used by the download pages to GOOS.
+var osNames = map[string]string{
+	"macos": "darwin",
+	"os x":  "darwin",
+	"osx":   "darwin",
+}
+
+// knownGoos is the set of GOOS values Go is distributed for.
+var knownGoos = map[string]bool{
+	"aix": true, "android": true, "darwin": true, "dragonfly": true, "freebsd": true, "illumos": true, "ios": true,
+	"js": true, "linux": true, "netbsd": true, "openbsd": true, "plan9": true, "solaris": true, "wasip1": true, "windows": true,
+}
+
+// archNames maps the architecture labels used by the download pages and file names to GOARCH.
+var archNames = map[string]string{
+	"x86-64":  "amd64",
+	"x86_64":  "amd64",
+	"x64":     "amd64",
+	"x86":     "386",
+	"i386":    "386",
+	"i686":    "386",
+	"armv5":   "arm",
+	"armv6":   "arm",
+	"armv6l":  "arm",
+	"armv7":   "arm",
+	"armv7l":  "arm",
+	"armv8":   "arm64",
+	"aarch64": "arm64",
+}
+
+// knownGoarch is the set of GOARCH values Go is distributed for.
+var knownGoarch = map[string]bool{
+	"386": true, "amd64": true, "arm": true, "arm64": true, "loong64": true, "mips": true, "mipsle": true,
+	"mips64": true, "mips64le": true, "ppc64": true, "ppc64le": true, "riscv64": true, "s390x": true, "wasm": true,
+}
+
+// CanonicalOS returns the GOOS for the operating system label, e.g. 'darwin' for 'macOS' or 'OS X 10.8+'.
+// It returns an empty string for unknown labels.
+func CanonicalOS(label string) string {
+	label = strings.ToLower(strings.TrimSpace(label))
+	if knownGoos[label] {
+		return label
+	}
+	for name, goos := range osNames {
+		if label == name || strings.HasPrefix(label, name+" ") {
+			return goos
+		}
+	}
+	return ""
+}
+
+// CanonicalArch returns the GOARCH for the architecture label, e.g. 'amd64' for 'x86-64' and 'arm' for 'ARMv6' or 'armv6l'.
+// It returns an empty string for unknown labels.
+func CanonicalArch(label string) string {
+	label = strings.ToLower(strings.TrimSpace(label))
+	if knownGoarch[label] {
+		return label
+	}
+	return archNames[label]
+}
+
+// platformReg matches the platform part of the package file name, e.g. '.linux-armv6l.' of 'go1.21.4.linux-armv6l.tar.gz'
+// or '.darwin-amd64-osx10.8.' of 'go1.4.3.darwin-amd64-osx10.8.pkg'.
+var platformReg = regexp.MustCompile(`\.([a-z0-9]+)-([a-z0-9_]+)(?:-([a-z0-9.]+?))?\.(?:tar\.gz|zip|msi|pkg)`)
+
+// ParsePlatform returns the GOOS and GOARCH of the package file name, e.g. 'linux' and 'arm' for 'go1.21.4.linux-armv6l.tar.gz'.
+// Both are empty for the source package and file names naming no known platform.
+func ParsePlatform(filename string) (goos, goarch string) {
+	match := platformReg.FindStringSubmatch(filename)
+	if match == nil {
+		return "", ""
+	}
+	goos, goarch = CanonicalOS(match[1]), CanonicalArch(match[2])
+	if goos == "" || goarch == "" {
+		return "", ""
+	}
+	return goos, goarch
+}
+
+// Goos returns the GOOS of the package, taken from its OS label or else from its file name.
+func (pkg *Package) Goos() string {
+	if goos := CanonicalOS(pkg.OS); goos != "" {
+		return goos
+	}
+	goos, _ := ParsePlatform(pkg.FileName)
+	return goos
+}
+
+// Goarch returns the GOARCH of the package, taken from its Arch label or else from its file name.
+func (pkg *Package) Goarch() string {
+	if goarch := CanonicalArch(pkg.Arch); goarch != "" {
+		return goarch
+	}
+	_, goarch := ParsePlatform(pkg.FileName)
+	return goarch
+}
+
+// matchPlatform reports whether the package is built for the GOOS and GOARCH.
+func (pkg *Package) matchPlatform(goos, goarch string) bool {
+	return pkg.Goos() == goos && pkg.Goarch() == goarch
+}
+
+var (
+	armLevelReg   = regexp.MustCompile(`(?i)armv(\d+)`)
+	amd64LevelReg = regexp.MustCompile(`-v(\d+)\.`)
+)
+
+// level returns the microarchitecture level the package is built for, i.e. the GOARM version of arm packages
+// (6 unless stated otherwise, e.g. 'armv7l') and the GOAMD64 version of amd64 packages (1 unless stated otherwise, e.g. 'amd64-v3').
+func (pkg *Package) level(goarch string) int {
+	var match []string
+	switch goarch {
+	case "arm":
+		if match = armLevelReg.FindStringSubmatch(pkg.FileName); match == nil {
+			match = armLevelReg.FindStringSubmatch(pkg.Arch)
+		}
+		if match == nil {
+			return 6
+		}
+	case "amd64":
+		if match = amd64LevelReg.FindStringSubmatch(pkg.FileName); match == nil {
+			return 1
+		}
+	default:
+		return 0
+	}
+	level, _ := strconv.Atoi(match[1])
+	return level
+}
+
+// levelHint returns the microarchitecture level requested by the GOARM (e.g. '7' or '7,softfloat') or GOAMD64 (e.g. 'v3')
+// environment variable, or 0 if there is none.
+func levelHint(goarch string) int {
+	var hint string
+	switch goarch {
+	case "arm":
+		hint, _, _ = strings.Cut(os.Getenv("GOARM"), ",")
+	case "amd64":
+		hint = strings.TrimPrefix(os.Getenv("GOAMD64"), "v")
+	}
+	level, _ := strconv.Atoi(hint)
+	return level
+}
+
+// sortByLevel orders the packages by the microarchitecture level hint: the highest level not above the hint comes first
+// and the packages requiring a higher level come last. The order is kept if there is no hint.
+func sortByLevel(pkgs []Package, goarch string) {
+	hint := levelHint(goarch)
+	if hint == 0 || len(pkgs) < 2 {
+		return
+	}
+	rank := func(pkg *Package) int {
+		level := pkg.level(goarch)
+		if level > hint {
+			return hint + level // unable to run on the machine described by the hint
+		}
+		return hint - level
+	}
+	sort.SliceStable(pkgs, func(i, j int) bool {
+		return rank(&pkgs[i]) < rank(&pkgs[j])
+	})
+}
@@ -0,0 +1,165 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanonicalOS(t *testing.T) {
+	tests := []struct {
+		label string
+		want  string
+	}{
+		{"Linux", "linux"},
+		{"macOS", "darwin"},
+		{"OS X 10.8+", "darwin"},
+		{"Windows", "windows"},
+		{"FreeBSD", "freebsd"},
+		{"illumos", "illumos"},
+		{"", ""},
+		{"BeOS", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.label, func(t *testing.T) {
+			assert.Equal(t, tt.want, CanonicalOS(tt.label))
+		})
+	}
+}
+
+func TestCanonicalArch(t *testing.T) {
+	tests := []struct {
+		label string
+		want  string
+	}{
+		{"x86-64", "amd64"},
+		{"x86", "386"},
+		{"ARMv6", "arm"},
+		{"armv6l", "arm"},
+		{"ARMv8", "arm64"},
+		{"ARM64", "arm64"},
+		{"loong64", "loong64"},
+		{"ppc64le", "ppc64le"},
+		{"riscv64", "riscv64"},
+		{"s390x", "s390x"},
+		{"", ""},
+		{"sparc", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.label, func(t *testing.T) {
+			assert.Equal(t, tt.want, CanonicalArch(tt.label))
+		})
+	}
+}
+
+func TestParsePlatform(t *testing.T) {
+	tests := []struct {
+		filename   string
+		wantGoos   string
+		wantGoarch string
+	}{
+		{"go1.21.4.linux-amd64.tar.gz", "linux", "amd64"},
+		{"go1.21.4.linux-armv6l.tar.gz", "linux", "arm"},
+		{"go1.21.4.linux-arm64.tar.gz", "linux", "arm64"},
+		{"go1.21.4.linux-loong64.tar.gz", "linux", "loong64"},
+		{"go1.21.4.freebsd-riscv64.tar.gz", "freebsd", "riscv64"},
+		{"go1.21.4.windows-386.msi", "windows", "386"},
+		{"go1.21.4.darwin-arm64.pkg.sha256", "darwin", "arm64"},
+		{"go1.4.3.darwin-amd64-osx10.8.pkg", "darwin", "amd64"},
+		{"go1.9.2rc2.linux-ppc64le.tar.gz", "linux", "ppc64le"},
+		{"go1.21.4.src.tar.gz", "", ""},
+		{"go1.4-bootstrap-20170531.tar.gz", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filename, func(t *testing.T) {
+			goos, goarch := ParsePlatform(tt.filename)
+			assert.Equal(t, tt.wantGoos, goos)
+			assert.Equal(t, tt.wantGoarch, goarch)
+		})
+	}
+}
+
+func TestPackage_Goos_Goarch(t *testing.T) {
+	t.Run("优先使用结构化的操作系统和架构", func(t *testing.T) {
+		pkg := Package{FileName: "go1.21.4.linux-armv6l.tar.gz", OS: "Linux", Arch: "ARMv6"}
+		assert.Equal(t, "linux", pkg.Goos())
+		assert.Equal(t, "arm", pkg.Goarch())
+	})
+
+	t.Run("缺少结构化信息时从文件名中获取", func(t *testing.T) {
+		pkg := Package{FileName: "go1.21.4.linux-armv6l.tar.gz"}
+		assert.Equal(t, "linux", pkg.Goos())
+		assert.Equal(t, "arm", pkg.Goarch())
+	})
+}
+
+func TestVersion_FindPackages_levelHint(t *testing.T) {
+	v := MustNew("1.21.4", WithPackages([]*Package{
+		{FileName: "go1.21.4.linux-armv7l.tar.gz", Kind: ArchiveKind},
+		{FileName: "go1.21.4.linux-armv6l.tar.gz", Kind: ArchiveKind},
+		{FileName: "go1.21.4.linux-amd64-v3.tar.gz", Kind: ArchiveKind},
+		{FileName: "go1.21.4.linux-amd64.tar.gz", Kind: ArchiveKind},
+	}))
+
+	t.Run("未设置GOARM时保持原有顺序", func(t *testing.T) {
+		t.Setenv("GOARM", "")
+		pkgs, err := v.FindPackages(ArchiveKind, "linux", "arm")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"go1.21.4.linux-armv7l.tar.gz", "go1.21.4.linux-armv6l.tar.gz"}, fileNames(pkgs))
+	})
+
+	t.Run("GOARM=6时优先选择armv6l", func(t *testing.T) {
+		t.Setenv("GOARM", "6")
+		pkgs, err := v.FindPackages(ArchiveKind, "linux", "arm")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"go1.21.4.linux-armv6l.tar.gz", "go1.21.4.linux-armv7l.tar.gz"}, fileNames(pkgs))
+	})
+
+	t.Run("GOARM=7,softfloat时优先选择armv7l", func(t *testing.T) {
+		t.Setenv("GOARM", "7,softfloat")
+		pkgs, err := v.FindPackages(ArchiveKind, "linux", "arm")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"go1.21.4.linux-armv7l.tar.gz", "go1.21.4.linux-armv6l.tar.gz"}, fileNames(pkgs))
+	})
+
+	t.Run("GOAMD64=v1时优先选择基础版本", func(t *testing.T) {
+		t.Setenv("GOAMD64", "v1")
+		pkgs, err := v.FindPackages(ArchiveKind, "linux", "amd64")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"go1.21.4.linux-amd64.tar.gz", "go1.21.4.linux-amd64-v3.tar.gz"}, fileNames(pkgs))
+	})
+
+	t.Run("GOAMD64=v4时优先选择v3版本", func(t *testing.T) {
+		t.Setenv("GOAMD64", "v4")
+		pkgs, err := v.FindPackages(ArchiveKind, "linux", "amd64")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"go1.21.4.linux-amd64-v3.tar.gz", "go1.21.4.linux-amd64.tar.gz"}, fileNames(pkgs))
+	})
+}
+
+func fileNames(pkgs []Package) []string {
+	names := make([]string, 0, len(pkgs))
+	for _, pkg := range pkgs {
+		names = append(names, pkg.FileName)
+	}
+	return names
+}
@@ -19,6 +19,7 @@
 
 package version
 
+// Collection sorts the versions in the ascending order of Go versions, e.g. '1.21rc2' precedes '1.21.0'.
 type Collection []*Version
 
 func (c Collection) Len() int {
@@ -26,7 +27,7 @@ func (c Collection) Len() int {
 }
 
 func (c Collection) Less(i, j int) bool {
-	return c[i].sv.LessThan(c[j].sv)
+	return Compare(c[i].name, c[j].name) < 0
 }
 
 func (c Collection) Swap(i, j int) {
@@ -49,4 +49,15 @@ func TestSort(t *testing.T) {
 	assert.Equal(t, vs[5].name, "1.21rc4")
 	assert.Equal(t, vs[6].name, "1.21.0")
 	assert.Equal(t, vs[7].name, "1.21.4")
+
+	t.Run("语言版本与补丁版本的候选版", func(t *testing.T) {
+		vs := []*Version{MustNew("1.9.2"), MustNew("1.21.0"), MustNew("1.9.2rc2"), MustNew("1.21"), MustNew("1.9.1"), MustNew("1.21rc1")}
+		sort.Sort(Collection(vs))
+
+		names := make([]string, 0, len(vs))
+		for _, v := range vs {
+			names = append(names, v.Name())
+		}
+		assert.Equal(t, []string{"1.9.1", "1.9.2rc2", "1.9.2", "1.21", "1.21rc1", "1.21.0"}, names)
+	})
 }
@@ -0,0 +1,129 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package version
+
+import (
+	"sort"
+	"strings"
+)
+
+// maxSuggestions is the maximum number of versions suggested for a version not found.
+const maxSuggestions = 3
+
+// Suggest returns the versions close to the version name not found among the versions, in the order of relevance:
+// the versions differing from the name by a typo (e.g. '1.21.2' for '1.212'), then the closest release of the requested line
+// (e.g. '1.21.13' for '1.21.99'). It returns nothing for the selectors and version constraints.
+func Suggest(vname string, items []*Version) []string {
+	vname = strings.TrimPrefix(vname, "go")
+	if !IsValid(vname) {
+		return nil
+	}
+
+	var suggestions []string
+	add := func(name string) {
+		for _, s := range suggestions {
+			if s == name {
+				return
+			}
+		}
+		suggestions = append(suggestions, name)
+	}
+
+	for i := len(items) - 1; i >= 0 && len(suggestions) < maxSuggestions-1; i-- { // Prefer higher versions first.
+		if isTypo(items[i].name, vname) {
+			add(items[i].name)
+		}
+	}
+	if v := closestRelease(vname, items); v != nil {
+		add(v.name)
+	}
+	return suggestions
+}
+
+// closestRelease returns the newest release of the line of the version name, or else the newest release older than it,
+// or else the oldest newer release. Prereleases are only considered if the version name is a prerelease.
+func closestRelease(vname string, items []*Version) *Version {
+	var line, older, newer *Version
+	for _, v := range items {
+		if IsPrerelease(v.name) && !IsPrerelease(vname) {
+			continue
+		}
+		switch {
+		case Lang(v.name) == Lang(vname):
+			if line == nil || Compare(v.name, line.name) > 0 {
+				line = v
+			}
+		case Compare(v.name, vname) < 0:
+			if older == nil || Compare(v.name, older.name) > 0 {
+				older = v
+			}
+		default:
+			if newer == nil || Compare(v.name, newer.name) < 0 {
+				newer = v
+			}
+		}
+	}
+	switch {
+	case line != nil:
+		return line
+	case older != nil:
+		return older
+	}
+	return newer
+}
+
+// isTypo reports whether the version names differ by misplaced dots (e.g. '1.212' and '1.21.2')
+// or by two swapped adjacent characters (e.g. '1.12.4' and '1.21.4').
+func isTypo(a, b string) bool {
+	if a == b {
+		return false
+	}
+	if strings.ReplaceAll(a, ".", "") == strings.ReplaceAll(b, ".", "") {
+		return true
+	}
+	if len(a) != len(b) {
+		return false
+	}
+	for i := 0; i < len(a)-1; i++ {
+		if a[i] != b[i] {
+			return a[i] == b[i+1] && a[i+1] == b[i] && a[i+2:] == b[i+2:]
+		}
+	}
+	return false
+}
+
+// platforms returns the platforms (e.g. 'linux/amd64') the version has a package of the kind for, all kinds if the kind is empty.
+func (v *Version) platforms(kind PackageKind) []string {
+	seen := make(map[string]bool, len(v.pkgs))
+	var items []string
+	for _, pkg := range v.pkgs {
+		if pkg == nil || kind != "" && !strings.EqualFold(string(pkg.Kind), string(kind)) {
+			continue
+		}
+		goos, goarch := pkg.Goos(), pkg.Goarch()
+		if goos == "" || goarch == "" || seen[goos+"/"+goarch] {
+			continue
+		}
+		seen[goos+"/"+goarch] = true
+		items = append(items, goos+"/"+goarch)
+	}
+	sort.Strings(items)
+	return items
+}
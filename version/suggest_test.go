@@ -0,0 +1,90 @@
+// Copyright (c) 2026 voidint <voidint@126.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/voidint/g/pkg/errs"
+)
+
+func TestSuggest(t *testing.T) {
+	vs, err := genVersions()
+	assert.Nil(t, err)
+
+	tests := []struct {
+		name  string
+		vname string
+		want  []string
+	}{
+		{name: "输入错误的版本号", vname: "1.212", want: []string{"1.21.2", "1.21.4"}},
+		{name: "带go前缀的版本号", vname: "go1.212", want: []string{"1.21.2", "1.21.4"}},
+		{name: "颠倒的数字", vname: "1.12.4", want: []string{"1.21.4", "1.12.17"}},
+		{name: "不存在的补丁版本", vname: "1.21.99", want: []string{"1.21.4"}},
+		{name: "不存在的次版本", vname: "1.99", want: []string{"1.21.4"}},
+		{name: "比所有版本都旧的版本", vname: "0.9", want: []string{"1"}},
+		{name: "不存在的预发布版本", vname: "1.21rc9", want: []string{"1.21.4"}},
+		{name: "版本约束", vname: "~1.99", want: nil},
+		{name: "选择器", vname: Stable, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Suggest(tt.vname, vs))
+		})
+	}
+}
+
+func TestFinder_suggestions(t *testing.T) {
+	vs, err := genVersions()
+	assert.Nil(t, err)
+
+	t.Run("版本不存在时给出建议", func(t *testing.T) {
+		_, err := NewFinder(vs, WithFinderGoos("linux"), WithFinderGoarch("amd64")).Find("1.212")
+		assert.True(t, errs.IsVersionNotFound(err))
+		assert.Equal(t, []string{"1.21.2", "1.21.4"}, err.(*errs.VersionNotFoundError).Suggestions())
+	})
+
+	t.Run("软件包不存在时给出可用平台", func(t *testing.T) {
+		_, err := NewFinder(vs, WithFinderGoos("windows"), WithFinderGoarch("s390x")).Find("1.21.4")
+		assert.True(t, errs.IsPackageNotFound(err))
+		e := err.(*errs.PackageNotFoundError)
+		assert.Equal(t, "1.21.4", e.Version())
+		assert.Contains(t, e.Platforms(), "linux/amd64")
+		assert.Contains(t, e.Platforms(), "linux/arm")
+		assert.NotContains(t, e.Platforms(), "windows/s390x")
+	})
+}
+
+func TestVersion_platforms(t *testing.T) {
+	v := MustNew("1.21.4", WithPackages([]*Package{
+		{FileName: "go1.21.4.src.tar.gz", Kind: SourceKind},
+		{FileName: "go1.21.4.linux-armv6l.tar.gz", Kind: ArchiveKind, OS: "Linux", Arch: "ARMv6"},
+		{FileName: "go1.21.4.darwin-amd64.tar.gz", Kind: ArchiveKind, OS: "macOS", Arch: "x86-64"},
+		{FileName: "go1.21.4.darwin-amd64.pkg", Kind: InstallerKind, OS: "macOS", Arch: "x86-64"},
+		{FileName: "go1.21.4.windows-386.msi", Kind: InstallerKind, OS: "Windows", Arch: "x86"},
+	}))
+	assert.Equal(t, []string{"darwin/amd64", "linux/arm"}, v.platforms(ArchiveKind))
+	assert.Equal(t, []string{"darwin/amd64", "windows/386"}, v.platforms(InstallerKind))
+	assert.Equal(t, []string{"darwin/amd64", "linux/arm", "windows/386"}, v.platforms(""))
+
+	_, err := v.FindPackages(ArchiveKind, "windows", "386")
+	assert.Equal(t, "package not found [windows,386,Archive], 1.21.4 is available for darwin/amd64, linux/arm", err.Error())
+}
@@ -20,14 +20,15 @@
 package version
 
 import (
-	"fmt"
 	"os"
 	"strings"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/voidint/g/pkg/checksum"
+	"github.com/voidint/g/pkg/checksumdb"
 	"github.com/voidint/g/pkg/errs"
 	httppkg "github.com/voidint/g/pkg/http"
+	"github.com/voidint/g/pkg/signature"
 )
 
 // Semantify converts Go version strings to semantic version format with adaptation.
@@ -118,7 +119,7 @@ func (v *Version) MatchConstraint(c *semver.Constraints) bool {
 
 func (v *Version) match(goos, goarch string) bool {
 	for _, pkg := range v.pkgs {
-		if strings.Contains(pkg.FileName, goos) && strings.Contains(pkg.FileName, goarch) { // TODO: Improve architecture matching logic
+		if pkg.matchPlatform(goos, goarch) {
 			return true
 		}
 	}
@@ -126,17 +127,21 @@ func (v *Version) match(goos, goarch string) bool {
 }
 
 // FindPackages discovers packages matching specific OS/ARCH and package type.
+// If several packages qualify, the one best suited to the GOARM or GOAMD64 environment variable comes first.
 func (v *Version) FindPackages(kind PackageKind, goos, goarch string) (pkgs []Package, err error) {
-	prefix := fmt.Sprintf("go%s.%s-%s", v.name, goos, goarch)
 	for i := range v.pkgs {
-		if v.pkgs[i] == nil || !strings.EqualFold(string(v.pkgs[i].Kind), string(kind)) || !strings.HasPrefix(v.pkgs[i].FileName, prefix) {
+		if v.pkgs[i] == nil || !strings.EqualFold(string(v.pkgs[i].Kind), string(kind)) || !v.pkgs[i].matchPlatform(goos, goarch) {
 			continue
 		}
 		pkgs = append(pkgs, *v.pkgs[i])
 	}
 	if len(pkgs) == 0 {
-		return nil, errs.NewPackageNotFoundError(string(kind), goos, goarch)
+		return nil, errs.NewPackageNotFoundError(string(kind), goos, goarch,
+			errs.WithPackageVersion(v.name),
+			errs.WithPlatforms(v.platforms(kind)...),
+		)
 	}
+	sortByLevel(pkgs, goarch)
 	return pkgs, nil
 }
 
@@ -151,6 +156,14 @@ type Package struct {
 	Checksum    string      `json:"checksum"`
 	ChecksumURL string      `json:"-"`
 	Algorithm   string      `json:"algorithm"` // checksum algorithm
+	// ChecksumLists are further aggregate checksum files of the mirror, consulted in turn when ChecksumURL has no entry for the package.
+	ChecksumLists []ChecksumList `json:"-"`
+}
+
+// ChecksumList is an aggregate checksum file covering many packages (e.g. 'SHA256SUMS').
+type ChecksumList struct {
+	URL       string
+	Algorithm string
 }
 
 // PackageKind indicates distribution package format type.
@@ -170,23 +183,94 @@ func (pkg *Package) DownloadWithProgress(dst string) (size int64, err error) {
 	return httppkg.Download(pkg.URL, dst, os.O_CREATE|os.O_WRONLY, 0644, true)
 }
 
+// SignatureURL returns the URL of the detached OpenPGP signature of the package.
+func (pkg *Package) SignatureURL() string {
+	return pkg.URL + ".asc"
+}
+
+// VerifySignature downloads the detached signature next to the downloaded file and validates the file against it.
+func (pkg *Package) VerifySignature(filename string, verifier *signature.Verifier) (err error) {
+	sigFilename := filename + ".asc"
+	if _, err = httppkg.Download(pkg.SignatureURL(), sigFilename, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644, false); err != nil {
+		_ = os.Remove(sigFilename)
+		if errs.IsURLUnreachable(err) {
+			return errs.ErrSignatureNotFound
+		}
+		return err
+	}
+	return verifier.VerifyFile(filename, sigFilename)
+}
+
+// HasChecksum reports whether the package comes with a checksum or a checksum file. The packages of official releases
+// coming without one take their checksum from the official checksum database.
+func (pkg *Package) HasChecksum() bool {
+	if pkg.Checksum == "" && pkg.ChecksumURL == "" {
+		if official, ok := checksumdb.Default().Lookup(pkg.FileName); ok {
+			pkg.Checksum, pkg.Algorithm = official, string(checksum.SHA256)
+		}
+	}
+	return pkg.Checksum != "" || pkg.ChecksumURL != ""
+}
+
 // VerifyChecksum validates downloaded file against cryptographic hash.
+// Packages of official releases are additionally verified against the official checksum database,
+// which also stands in for the checksum of packages that come without one, or whose checksum file is missing or has no entry for them.
 func (pkg *Package) VerifyChecksum(filename string) (err error) {
+	official, hasOfficial := checksumdb.Default().Lookup(pkg.FileName)
+	pkg.HasChecksum()
+
 	if pkg.Checksum == "" && pkg.ChecksumURL != "" {
-		data, err := httppkg.DownloadAsBytes(pkg.ChecksumURL)
-		if err != nil {
+		entry, err := pkg.fetchChecksum()
+		switch {
+		case err == nil:
+			pkg.Checksum = entry.Digest
+			if entry.Algorithm != "" {
+				pkg.Algorithm = string(entry.Algorithm)
+			}
+		case hasOfficial:
+			pkg.Checksum, pkg.Algorithm = official, string(checksum.SHA256)
+		default:
 			return err
 		}
-		pkg.Checksum = string(data)
-	}
-	var algo checksum.Algorithm
-	switch pkg.Algorithm {
-	case string(checksum.SHA256):
-		algo = checksum.SHA256
-	case string(checksum.SHA1):
-		algo = checksum.SHA1
-	default:
-		return errs.ErrUnsupportedChecksumAlgorithm
-	}
-	return checksum.VerifyFile(algo, pkg.Checksum, filename)
+	}
+
+	algo, err := checksum.ParseAlgorithm(pkg.Algorithm)
+	if err != nil || algo.Size()*2 != len(pkg.Checksum) {
+		// The algorithm is unknown or disagrees with the digest, so try to infer it from the digest instead.
+		if detected, e := checksum.DetectAlgorithm(pkg.Checksum); e == nil {
+			algo, err = detected, nil
+			pkg.Algorithm = string(algo)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if err = checksum.VerifyFile(algo, pkg.Checksum, filename); err != nil {
+		return err
+	}
+	if hasOfficial && !strings.EqualFold(pkg.Checksum, official) {
+		return checksum.VerifyFile(checksum.SHA256, official, filename)
+	}
+	return nil
+}
+
+// fetchChecksum returns the checksum entry of the package in the checksum file of ChecksumURL,
+// or else in the first of ChecksumLists having one.
+func (pkg *Package) fetchChecksum() (checksum.Entry, error) {
+	entry, err := lookupChecksum(pkg.ChecksumURL, pkg.FileName)
+	for i := 0; err != nil && i < len(pkg.ChecksumLists); i++ {
+		if entry, err = lookupChecksum(pkg.ChecksumLists[i].URL, pkg.FileName); err == nil && entry.Algorithm == "" {
+			entry.Algorithm = checksum.Algorithm(pkg.ChecksumLists[i].Algorithm)
+		}
+	}
+	return entry, err
+}
+
+// lookupChecksum returns the checksum entry of the file in the checksum file of the URL.
+func lookupChecksum(checksumURL, filename string) (checksum.Entry, error) {
+	data, err := httppkg.DownloadAsBytes(checksumURL)
+	if err != nil {
+		return checksum.Entry{}, err
+	}
+	return checksum.Lookup(data, filename)
 }
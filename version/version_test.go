@@ -20,18 +20,29 @@
 package version
 
 import (
+	"bytes"
 	"crypto/sha1"
 	"crypto/sha256"
+	"crypto/sha512"
 	"fmt"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/Masterminds/semver/v3"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/agiledragon/gomonkey/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/voidint/g/pkg/checksumdb"
 	"github.com/voidint/g/pkg/errs"
+	httppkg "github.com/voidint/g/pkg/http"
+	"github.com/voidint/g/pkg/signature"
 )
 
 func TestSemantify(t *testing.T) {
@@ -158,11 +169,29 @@ func TestVersion_FindPackages(t *testing.T) {
 	})
 
 	t.Run("查找到多个软件包", func(t *testing.T) {
+		v122 := MustNew("1.2.2", WithPackages([]*Package{
+			{FileName: "go1.2.2.darwin-386-osx10.6.tar.gz", Kind: ArchiveKind, OS: "OS X 10.6+", Arch: "x86"},
+			{FileName: "go1.2.2.darwin-386-osx10.8.tar.gz", Kind: ArchiveKind, OS: "OS X 10.8+", Arch: "x86"},
+			{FileName: "go1.2.2.darwin-amd64-osx10.8.tar.gz", Kind: ArchiveKind, OS: "OS X 10.8+", Arch: "x86-64"},
+		}))
+
+		pkgs, err := v122.FindPackages(ArchiveKind, "darwin", "386")
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(pkgs))
+	})
+
+	t.Run("arm不匹配arm64的软件包", func(t *testing.T) {
 		v1214 := vs[len(vs)-1] // 1.21.4
 
 		pkgs, err := v1214.FindPackages(ArchiveKind, "linux", "arm")
 		assert.Nil(t, err)
-		assert.Equal(t, 2, len(pkgs))
+		assert.Equal(t, 1, len(pkgs))
+		assert.Equal(t, "go1.21.4.linux-armv6l.tar.gz", pkgs[0].FileName)
+
+		pkgs, err = v1214.FindPackages(ArchiveKind, "linux", "arm64")
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(pkgs))
+		assert.Equal(t, "go1.21.4.linux-arm64.tar.gz", pkgs[0].FileName)
 	})
 
 	t.Run("未查找到软件包", func(t *testing.T) {
@@ -313,6 +342,130 @@ func TestVerifyChecksum(t *testing.T) {
 			assert.Equal(t, errs.ErrChecksumNotMatched, pkg.VerifyChecksum(filename))
 		})
 
+		t.Run("聚合校验和文件", func(t *testing.T) {
+			_, _ = f.Seek(0, 0)
+			h := sha512.New()
+			_, err = io.Copy(h, f)
+			assert.Nil(t, err)
+
+			patches := gomonkey.ApplyFuncReturn(httppkg.DownloadAsBytes, []byte(fmt.Sprintf(
+				"%x  go1.21.4.linux-amd64.tar.gz\n%x  %s\n", sha256.Sum256(nil), h.Sum(nil), filename,
+			)), nil)
+			defer patches.Reset()
+
+			pkg := &Package{
+				FileName:    filename,
+				Algorithm:   "SHA256",
+				ChecksumURL: "https://example.com/golang/SHA512SUMS",
+			}
+			assert.Nil(t, pkg.VerifyChecksum(filename))
+			assert.Equal(t, "SHA512", pkg.Algorithm)
+
+			pkg = &Package{
+				FileName:    "go1.99.0.windows-amd64.zip",
+				ChecksumURL: "https://example.com/golang/SHA512SUMS",
+			}
+			assert.Equal(t, errs.ErrChecksumEntryNotFound, pkg.VerifyChecksum(filename))
+		})
+
+		t.Run("在多个聚合校验和文件中查找", func(t *testing.T) {
+			_, _ = f.Seek(0, 0)
+			h := sha256.New()
+			_, err = io.Copy(h, f)
+			assert.Nil(t, err)
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/SHA512SUMS":
+					_, _ = fmt.Fprintf(w, "%x  go1.21.4.linux-amd64.tar.gz\n", sha512.Sum512(nil))
+				case "/SHA256SUMS":
+					_, _ = fmt.Fprintf(w, "%x  %s\n", h.Sum(nil), filename)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer srv.Close()
+
+			pkg := &Package{
+				FileName:      filename,
+				Algorithm:     "SHA512",
+				ChecksumURL:   srv.URL + "/SHA512SUMS",
+				ChecksumLists: []ChecksumList{{URL: srv.URL + "/SHA256SUMS", Algorithm: "SHA256"}},
+			}
+			assert.Nil(t, pkg.VerifyChecksum(filename))
+			assert.Equal(t, fmt.Sprintf("%x", h.Sum(nil)), pkg.Checksum)
+			assert.Equal(t, "SHA256", pkg.Algorithm)
+
+			pkg = &Package{
+				FileName:      "go1.99.0.windows-amd64.zip",
+				ChecksumURL:   srv.URL + "/SHA512SUMS",
+				ChecksumLists: []ChecksumList{{URL: srv.URL + "/SHA256SUMS", Algorithm: "SHA256"}},
+			}
+			assert.Equal(t, errs.ErrChecksumEntryNotFound, pkg.VerifyChecksum(filename))
+		})
+
+		t.Run("校验和文件缺失或无记录时使用官方校验和数据库", func(t *testing.T) {
+			_, _ = f.Seek(0, 0)
+			h := sha256.New()
+			_, err = io.Copy(h, f)
+			assert.Nil(t, err)
+
+			sums := filepath.Join(t.TempDir(), "SHA256SUMS")
+			assert.Nil(t, os.WriteFile(sums, []byte(fmt.Sprintf("%x  go1.99.0.linux-amd64.tar.gz\n", h.Sum(nil))), 0644))
+			db, err := checksumdb.New(sums)
+			assert.Nil(t, err)
+			defer checksumdb.SetDefault(checksumdb.Default())
+			checksumdb.SetDefault(db)
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/SHA256SUMS" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				_, _ = fmt.Fprintf(w, "%x  go1.99.0.darwin-arm64.tar.gz\n", sha256.Sum256(nil))
+			}))
+			defer srv.Close()
+
+			for _, checksumURL := range []string{srv.URL + "/go1.99.0.linux-amd64.tar.gz.sha256", srv.URL + "/SHA256SUMS"} {
+				pkg := &Package{FileName: "go1.99.0.linux-amd64.tar.gz", ChecksumURL: checksumURL}
+				assert.Nil(t, pkg.VerifyChecksum(filename))
+				assert.Equal(t, fmt.Sprintf("%x", h.Sum(nil)), pkg.Checksum)
+				assert.Equal(t, "SHA256", pkg.Algorithm)
+			}
+
+			pkg := &Package{FileName: "go1.99.0.linux-arm64.tar.gz", ChecksumURL: srv.URL + "/go1.99.0.linux-arm64.tar.gz.sha256"}
+			assert.True(t, errs.IsURLUnreachable(pkg.VerifyChecksum(filename)))
+			pkg = &Package{FileName: "go1.99.0.linux-arm64.tar.gz", ChecksumURL: srv.URL + "/SHA256SUMS"}
+			assert.Equal(t, errs.ErrChecksumEntryNotFound, pkg.VerifyChecksum(filename))
+		})
+
+		t.Run("官方校验和数据库", func(t *testing.T) {
+			_, _ = f.Seek(0, 0)
+			h := sha256.New()
+			_, err = io.Copy(h, f)
+			assert.Nil(t, err)
+
+			sums := filepath.Join(t.TempDir(), "SHA256SUMS")
+			assert.Nil(t, os.WriteFile(sums, []byte(fmt.Sprintf("%x  %s\n", h.Sum(nil), filename)), 0644))
+			db, err := checksumdb.New(sums)
+			assert.Nil(t, err)
+			defer checksumdb.SetDefault(checksumdb.Default())
+			checksumdb.SetDefault(db)
+
+			pkg := &Package{FileName: filename}
+			assert.Nil(t, pkg.VerifyChecksum(filename))
+			assert.Equal(t, "SHA256", pkg.Algorithm)
+
+			// The checksum from the mirror matches, but the official one does not.
+			assert.Nil(t, os.WriteFile(sums, []byte(fmt.Sprintf("%x  %s\n", sha256.Sum256(nil), filename)), 0644))
+			db, err = checksumdb.New(sums)
+			assert.Nil(t, err)
+			checksumdb.SetDefault(db)
+
+			pkg = &Package{FileName: filename, Algorithm: "SHA256", Checksum: fmt.Sprintf("%x", h.Sum(nil))}
+			assert.Equal(t, errs.ErrChecksumNotMatched, pkg.VerifyChecksum(filename))
+		})
+
 		t.Run("SHA1024", func(t *testing.T) {
 			pkg := &Package{
 				Algorithm: "SHA1024",
@@ -321,3 +474,71 @@ func TestVerifyChecksum(t *testing.T) {
 		})
 	})
 }
+
+func TestPackage_VerifySignature(t *testing.T) {
+	dir := t.TempDir()
+	entity, err := openpgp.NewEntity("g", "test", "g@example.com", nil)
+	assert.Nil(t, err)
+
+	keyring := filepath.Join(dir, "keyring.gpg")
+	var buf bytes.Buffer
+	assert.Nil(t, entity.Serialize(&buf))
+	assert.Nil(t, os.WriteFile(keyring, buf.Bytes(), 0644))
+	verifier, err := signature.NewVerifier(keyring)
+	assert.Nil(t, err)
+
+	buf.Reset()
+	assert.Nil(t, openpgp.ArmoredDetachSign(&buf, entity, strings.NewReader("hello world"), nil))
+	sig := buf.Bytes()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/go1.21.4.linux-amd64.tar.gz.asc" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(sig)
+	}))
+	defer srv.Close()
+
+	filename := filepath.Join(dir, "go1.21.4.linux-amd64.tar.gz")
+	assert.Nil(t, os.WriteFile(filename, []byte("hello world"), 0644))
+
+	t.Run("签名有效", func(t *testing.T) {
+		pkg := &Package{URL: srv.URL + "/go1.21.4.linux-amd64.tar.gz"}
+		assert.Equal(t, srv.URL+"/go1.21.4.linux-amd64.tar.gz.asc", pkg.SignatureURL())
+		assert.Nil(t, pkg.VerifySignature(filename, verifier))
+	})
+
+	t.Run("签名文件不存在", func(t *testing.T) {
+		pkg := &Package{URL: srv.URL + "/go1.21.4.darwin-arm64.tar.gz"}
+		assert.Equal(t, errs.ErrSignatureNotFound, pkg.VerifySignature(filename, verifier))
+		_, err := os.Stat(filename + ".asc")
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("文件被篡改", func(t *testing.T) {
+		assert.Nil(t, os.WriteFile(filename, []byte("hello 世界"), 0644))
+		pkg := &Package{URL: srv.URL + "/go1.21.4.linux-amd64.tar.gz"}
+		assert.Equal(t, errs.ErrSignatureNotMatched, pkg.VerifySignature(filename, verifier))
+	})
+}
+
+func TestPackage_HasChecksum(t *testing.T) {
+	t.Run("自带校验和", func(t *testing.T) {
+		pkg := Package{FileName: "go1.99.0.linux-amd64.tar.gz", ChecksumURL: "https://example.com/go1.99.0.linux-amd64.tar.gz.sha256"}
+		assert.True(t, pkg.HasChecksum())
+		assert.Equal(t, "", pkg.Checksum)
+	})
+
+	t.Run("使用官方校验和数据库", func(t *testing.T) {
+		pkg := Package{FileName: "go1.21.4.linux-amd64.tar.gz"}
+		assert.True(t, pkg.HasChecksum())
+		assert.Equal(t, "73cac0215254d0c7d1241fa40837851f3b9a8a742d0b54714cbdfb3feaf8f0af", pkg.Checksum)
+		assert.Equal(t, "SHA256", pkg.Algorithm)
+	})
+
+	t.Run("缺少校验和", func(t *testing.T) {
+		pkg := Package{FileName: "go1.99.0.linux-amd64.tar.gz"}
+		assert.False(t, pkg.HasChecksum())
+	})
+}